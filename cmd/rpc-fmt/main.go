@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/newlix/rpc/internal/canonical"
+	"github.com/newlix/rpc/schema"
+)
+
+func main() {
+	path := flag.String("schema", "schema.json", "Path to the schema file")
+	to := flag.String("format", "", "Output format, json or yaml, defaulting to the format of the schema file")
+	write := flag.Bool("w", false, "Write the result to the schema file instead of stdout, comments are not preserved")
+	flag.Parse()
+
+	from := formatOf(*path)
+	if *to == "" {
+		*to = from
+	}
+
+	if *write && *to != from {
+		log.Fatalf("error: cannot write %s to %s file %q", *to, from, *path)
+	}
+
+	b, err := os.ReadFile(*path)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	err = generate(&buf, *path, b, *to)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	if *write {
+		err = os.WriteFile(*path, buf.Bytes(), 0644)
+	} else {
+		_, err = io.Copy(os.Stdout, &buf)
+	}
+
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
+
+// generate implementation.
+func generate(w io.Writer, path string, b []byte, format string) error {
+	b, err := schema.Normalize(path, b)
+	if err != nil {
+		return fmt.Errorf("normalizing: %w", err)
+	}
+
+	err = canonical.Write(w, b, format)
+	if err != nil {
+		return fmt.Errorf("formatting: %w", err)
+	}
+
+	return nil
+}
+
+// formatOf returns the format of the schema file at path.
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/newlix/rpc/internal/canonical"
+	"github.com/newlix/rpc/internal/fromgo"
+)
+
+func main() {
+	pkg := flag.String("package", ".", "Pattern of the package declaring the interface")
+	iface := flag.String("interface", "", "Name of the interface declaring the methods")
+	name := flag.String("name", "", "Name of the API, defaulting to the package name")
+	version := flag.String("version", "1.0.0", "Version of the API")
+	format := flag.String("format", "json", "Output format, json or yaml")
+	flag.Parse()
+
+	if *iface == "" {
+		log.Fatalf("error: -interface is required")
+	}
+
+	b, err := fromgo.Generate(fromgo.Config{
+		Package:   *pkg,
+		Interface: *iface,
+		Name:      *name,
+		Version:   *version,
+	})
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	err = canonical.Write(os.Stdout, b, *format)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
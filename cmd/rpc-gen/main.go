@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/newlix/rpc/internal/targets"
+	"github.com/newlix/rpc/schema"
+)
+
+func main() {
+	path := flag.String("config", "rpc.yaml", "Path to the config file")
+	check := flag.Bool("check", false, "Check the generated files are up to date instead of writing them")
+	flag.Parse()
+
+	c, err := targets.LoadConfig(*path)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	s, err := schema.Load(c.Schema)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	// generate every target before writing any file
+	var files []targets.File
+	for _, t := range c.Targets {
+		v, err := targets.Generate(s, t)
+		if err != nil {
+			log.Fatalf("error: %s: %s", t.Output, err)
+		}
+		files = append(files, v...)
+	}
+
+	if *check {
+		stale := 0
+		for _, f := range files {
+			b, err := os.ReadFile(f.Path)
+			if err != nil && !os.IsNotExist(err) {
+				log.Fatalf("error: %s", err)
+			}
+
+			if err != nil || !bytes.Equal(b, f.Content) {
+				fmt.Fprintf(os.Stderr, "%s is stale\n", f.Path)
+				stale++
+			}
+		}
+
+		if stale > 0 {
+			log.Fatalf("error: %d stale generated files, run rpc-gen to update them", stale)
+		}
+		return
+	}
+
+	for _, f := range files {
+		err := targets.WriteFile(f.Path, f.Content)
+		if err != nil {
+			log.Fatalf("error: %s", err)
+		}
+	}
+}
@@ -2,13 +2,11 @@ package main
 
 import (
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
-	"path"
 
 	"github.com/newlix/rpc/generators/goserver"
+	"github.com/newlix/rpc/internal/targets"
 	"github.com/newlix/rpc/schema"
 )
 
@@ -16,6 +14,8 @@ func main() {
 	path := flag.String("schema", "schema.json", "Path to the schema file")
 	pkg := flag.String("package", "server", "Name of the package")
 	types := flag.String("types", "", "Types package to import")
+	readonly := flag.String("readonly", goserver.Ignore, "Read-only fields in requests: ignore or reject")
+	embed := flag.String("embed", "", "Path of the schema file to embed and serve, relative to the package")
 	flag.Parse()
 
 	s, err := schema.Load(*path)
@@ -23,38 +23,8 @@ func main() {
 		log.Fatalf("error: %s", err)
 	}
 
-	err = generate(os.Stdout, s, *pkg, *types)
+	err = targets.GoServer(os.Stdout, s, *pkg, *types, *readonly, *embed)
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
 }
-
-// generate implementation.
-func generate(w io.Writer, s *schema.Schema, pkg, types string) error {
-	out := fmt.Fprintf
-
-	// TODO: move these to generator
-	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
-	out(w, "package %s\n\n", pkg)
-
-	out(w, "import (\n")
-	out(w, "  \"context\"\n")
-	out(w, "  \"net/http\"\n")
-	out(w, "\n")
-	out(w, "  \"github.com/newlix/rpc\"\n")
-	if len(types) > 0 {
-		out(w, "\n")
-		out(w, "  \"%s\"\n", types)
-	}
-	out(w, ")\n\n")
-
-	if len(types) > 0 {
-		types = path.Base(types)
-	}
-	err := goserver.Generate(w, s, types)
-	if err != nil {
-		return fmt.Errorf("generating client: %w", err)
-	}
-
-	return nil
-}
@@ -2,18 +2,18 @@ package main
 
 import (
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
 
-	"github.com/newlix/rpc/generators/kotlinclient"
+	"github.com/newlix/rpc/internal/targets"
 	"github.com/newlix/rpc/schema"
 )
 
 func main() {
 	path := flag.String("schema", "schema.json", "Path to the schema file")
 	pkg := flag.String("package", "com.example", "Name of the package")
+	visibility := flag.String("visibility", "public", "Methods to include: public, internal or all")
+	groups := flag.String("groups", "", "Comma-separated names of the groups to include, defaults to all")
 	flag.Parse()
 
 	s, err := schema.Load(*path)
@@ -21,22 +21,13 @@ func main() {
 		log.Fatalf("error: %s", err)
 	}
 
-	err = generate(os.Stdout, s, *pkg)
+	s, err = targets.Filter(s, *visibility, *groups)
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
-}
 
-// generate implementation.
-func generate(w io.Writer, s *schema.Schema, pkg string) error {
-	out := fmt.Fprintf
-	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
-	out(w, "@file:Suppress(\"unused\")\n")
-	out(w, "package %s\n\n", pkg)
-	err := kotlinclient.Generate(w, s)
+	err = targets.KotlinClient(os.Stdout, s, *pkg)
 	if err != nil {
-		return fmt.Errorf("generating client: %w", err)
+		log.Fatalf("error: %s", err)
 	}
-
-	return nil
 }
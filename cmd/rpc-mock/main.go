@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/newlix/rpc/internal/mock"
+	"github.com/newlix/rpc/schema"
+)
+
+func main() {
+	path := flag.String("schema", "schema.json", "Path to the schema file")
+	address := flag.String("address", "localhost:3000", "Bind address")
+	latency := flag.Duration("latency", 0, "Delay before responding to each method call, such as 250ms")
+	errorRate := flag.Float64("error-rate", 0, "Fraction of method calls responding with an internal server error, between 0 and 1")
+	flag.Parse()
+
+	s, err := schema.Load(*path)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	if *errorRate < 0 || *errorRate > 1 {
+		log.Fatalf("error: -error-rate must be between 0 and 1")
+	}
+
+	h := &mock.Server{
+		Schema:    s,
+		Latency:   *latency,
+		ErrorRate: *errorRate,
+	}
+
+	log.Printf("serving %d methods of %s on %s", len(s.Methods), s.Name, *address)
+	err = http.ListenAndServe(*address, logger(h))
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
+
+// logger logs the requests to h.
+func logger(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL.Path)
+		h.ServeHTTP(w, r)
+	})
+}
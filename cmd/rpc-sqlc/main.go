@@ -1,14 +1,11 @@
 package main
 
 import (
+	"bytes"
 	"flag"
-	"fmt"
-	"io"
 	"log"
-	"os"
-	"path/filepath"
 
-	"github.com/newlix/rpc/generators/sqlc"
+	"github.com/newlix/rpc/internal/targets"
 	"github.com/newlix/rpc/schema"
 )
 
@@ -17,6 +14,7 @@ func main() {
 	pkg := flag.String("package", "model", "Name of the package")
 	schemaSQL := flag.String("schema-sql", "sql/schema/schema.sql", "Path to the schema.sql")
 	query := flag.String("query", "model/query.apex.go", "Path to the basic crud query")
+	migrate := flag.String("migrate", "", "Path to the data migration, not generated when empty")
 	flag.Parse()
 
 	s, err := schema.Load(*path)
@@ -24,67 +22,29 @@ func main() {
 		log.Fatalf("error: %s", err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(*schemaSQL), os.ModePerm); err != nil {
+	var b bytes.Buffer
+	if err := targets.SQLCSchema(&b, s); err != nil {
 		log.Fatal(err)
 	}
-	if f, err := os.Create(*schemaSQL); err != nil {
+	if err := targets.WriteFile(*schemaSQL, b.Bytes()); err != nil {
 		log.Fatal(err)
-	} else {
-		if err := generateSQLCSchema(f, s); err != nil {
-			log.Fatal(err)
-		}
 	}
 
-	if err := os.MkdirAll(filepath.Dir(*query), os.ModePerm); err != nil {
+	b.Reset()
+	if err := targets.SQLCQuery(&b, s, *pkg); err != nil {
 		log.Fatal(err)
 	}
-
-	if f, err := os.Create(*query); err != nil {
+	if err := targets.WriteFile(*query, b.Bytes()); err != nil {
 		log.Fatal(err)
-	} else {
-		if err := generateCRUDQuery(f, s, *pkg); err != nil {
-			log.Fatal(err)
-		}
 	}
 
-	if f, err := os.Create("tmp/migrate.go"); err != nil {
-		log.Fatal(err)
-	} else {
-		if err := sqlc.GenerateMigrate(f, s); err != nil {
+	if *migrate != "" {
+		b.Reset()
+		if err := targets.SQLCMigrate(&b, s); err != nil {
+			log.Fatal(err)
+		}
+		if err := targets.WriteFile(*migrate, b.Bytes()); err != nil {
 			log.Fatal(err)
 		}
 	}
-
-}
-
-// generate implementation.
-func generateSQLCSchema(w io.Writer, s *schema.Schema) error {
-	out := fmt.Fprintf
-
-	out(w, "-- Do not edit, this file was generated by github.com/newlix/rpc/cmd/rpc-sqlc.\n\n")
-
-	err := sqlc.GenerateSchema(w, s)
-	if err != nil {
-		return fmt.Errorf("generating sqlc schema: %w", err)
-	}
-
-	return nil
-}
-
-func generateCRUDQuery(w io.Writer, s *schema.Schema, pkg string) error {
-	out := fmt.Fprintf
-
-	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
-	out(w, "package %s\n\n", pkg)
-
-	out(w, "import (\n")
-	out(w, "  \"context\"\n")
-	out(w, ")\n\n")
-
-	err := sqlc.GenerateQuery(w, s)
-	if err != nil {
-		return fmt.Errorf("generating sqlc query: %w", err)
-	}
-
-	return nil
 }
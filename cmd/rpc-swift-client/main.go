@@ -2,18 +2,18 @@ package main
 
 import (
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
 
-	"github.com/newlix/rpc/generators/swiftclient"
+	"github.com/newlix/rpc/internal/targets"
 	"github.com/newlix/rpc/schema"
 )
 
 func main() {
 	path := flag.String("schema", "schema.json", "Path to the schema file")
 	client := flag.String("client", "Client", "Name for the client")
+	visibility := flag.String("visibility", "public", "Methods to include: public, internal or all")
+	groups := flag.String("groups", "", "Comma-separated names of the groups to include, defaults to all")
 	flag.Parse()
 
 	s, err := schema.Load(*path)
@@ -21,21 +21,13 @@ func main() {
 		log.Fatalf("error: %s", err)
 	}
 
-	err = generate(os.Stdout, s, *client)
+	s, err = targets.Filter(s, *visibility, *groups)
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
-}
-
-// generate implementation.
-func generate(w io.Writer, s *schema.Schema, client string) error {
-	out := fmt.Fprintf
-	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
 
-	err := swiftclient.Generate(w, s, client)
+	err = targets.SwiftClient(os.Stdout, s, *client)
 	if err != nil {
-		return fmt.Errorf("generating client: %w", err)
+		log.Fatalf("error: %s", err)
 	}
-
-	return nil
 }
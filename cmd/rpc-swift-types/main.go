@@ -2,17 +2,18 @@ package main
 
 import (
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
 
-	"github.com/newlix/rpc/generators/swifttypes"
+	"github.com/newlix/rpc/internal/targets"
 	"github.com/newlix/rpc/schema"
 )
 
 func main() {
 	path := flag.String("schema", "schema.json", "Path to the schema file")
+	validate := flag.Bool("validate", true, "Generate validation methods")
+	visibility := flag.String("visibility", "public", "Methods to include: public, internal or all")
+	groups := flag.String("groups", "", "Comma-separated names of the groups to include, defaults to all")
 	flag.Parse()
 
 	s, err := schema.Load(*path)
@@ -20,20 +21,13 @@ func main() {
 		log.Fatalf("error: %s", err)
 	}
 
-	err = generate(os.Stdout, s)
+	s, err = targets.Filter(s, *visibility, *groups)
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
-}
 
-// generate implementation.
-func generate(w io.Writer, s *schema.Schema) error {
-	out := fmt.Fprintf
-	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
-	err := swifttypes.Generate(w, s, false)
+	err = targets.SwiftTypes(os.Stdout, s, *validate)
 	if err != nil {
-		return fmt.Errorf("generating types: %w", err)
+		log.Fatalf("error: %s", err)
 	}
-
-	return nil
 }
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/newlix/rpc/generators/tmpl"
+	"github.com/newlix/rpc/internal/targets"
+	"github.com/newlix/rpc/schema"
+)
+
+func main() {
+	path := flag.String("schema", "schema.json", "Path to the schema file")
+	template := flag.String("t", "", "Path to the template file")
+	visibility := flag.String("visibility", "all", "Methods to include: public, internal or all")
+	groups := flag.String("groups", "", "Comma-separated names of the groups to include, defaults to all")
+	flag.Parse()
+
+	if *template == "" {
+		log.Fatalf("error: -t is required")
+	}
+
+	s, err := schema.Load(*path)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	s, err = targets.Filter(s, *visibility, *groups)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	err = tmpl.Generate(os.Stdout, s, *template)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
@@ -2,19 +2,21 @@ package main
 
 import (
 	"flag"
-	"fmt"
-	"io"
 	"log"
 	"os"
 
-	"github.com/newlix/rpc/generators/tsclient"
 	"github.com/newlix/rpc/generators/tstypes"
+	"github.com/newlix/rpc/internal/targets"
 	"github.com/newlix/rpc/schema"
 )
 
 func main() {
 	path := flag.String("schema", "schema.json", "Path to the schema file")
-	fetchLibrary := flag.String("fetch-library", "node-fetch", "Module import for the fetch library")
+	fetchLibrary := flag.String("fetch-library", "", "Module import for the default fetch library, defaults to the global fetch")
+	types := flag.String("types", "", "Module import for the types, defaults to including them")
+	mode := flag.String("mode", "ts", "Output: ts, js or d.ts")
+	visibility := flag.String("visibility", "public", "Methods to include: public, internal or all")
+	groups := flag.String("groups", "", "Comma-separated names of the groups to include, defaults to all")
 	flag.Parse()
 
 	s, err := schema.Load(*path)
@@ -22,27 +24,13 @@ func main() {
 		log.Fatalf("error: %s", err)
 	}
 
-	err = generate(os.Stdout, s, "client", *fetchLibrary)
+	s, err = targets.Filter(s, *visibility, *groups)
 	if err != nil {
 		log.Fatalf("error: %s", err)
 	}
-}
-
-// generate implementation.
-func generate(w io.Writer, s *schema.Schema, pkg, fetchLibrary string) error {
-	out := fmt.Fprintf
-
-	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
-
-	err := tstypes.Generate(w, s)
-	if err != nil {
-		return fmt.Errorf("generating types: %w", err)
-	}
 
-	err = tsclient.Generate(w, s, fetchLibrary)
+	err = targets.TSClient(os.Stdout, s, *fetchLibrary, *types, tstypes.Mode(*mode))
 	if err != nil {
-		return fmt.Errorf("generating client: %w", err)
+		log.Fatalf("error: %s", err)
 	}
-
-	return nil
 }
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/newlix/rpc/generators/tstypes"
+	"github.com/newlix/rpc/internal/targets"
+	"github.com/newlix/rpc/schema"
+)
+
+func main() {
+	path := flag.String("schema", "schema.json", "Path to the schema file")
+	mode := flag.String("mode", "ts", "Output: ts, js or d.ts")
+	visibility := flag.String("visibility", "public", "Methods to include: public, internal or all")
+	groups := flag.String("groups", "", "Comma-separated names of the groups to include, defaults to all")
+	flag.Parse()
+
+	s, err := schema.Load(*path)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	s, err = targets.Filter(s, *visibility, *groups)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	err = targets.TSTypes(os.Stdout, s, tstypes.Mode(*mode))
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/newlix/rpc/rpctest"
+	"github.com/newlix/rpc/schema"
+)
+
+func main() {
+	path := flag.String("schema", "schema.json", "Path to the schema file")
+	url := flag.String("url", "", "URL of the server to verify")
+	token := flag.String("token", "", "Auth token sent as a bearer token")
+	ignore := flag.String("ignore", "", "Comma-separated output paths to ignore, such as item.id,items[].created_at")
+	flag.Parse()
+
+	if *url == "" {
+		log.Fatalf("error: -url is required")
+	}
+
+	s, err := schema.Load(*path)
+	if err != nil {
+		log.Fatalf("error: %s", err)
+	}
+
+	var paths []string
+	if *ignore != "" {
+		paths = strings.Split(*ignore, ",")
+	}
+
+	h := remote{url: strings.TrimRight(*url, "/"), token: *token}
+	if !verify(os.Stdout, h, s, paths) {
+		os.Exit(1)
+	}
+}
+
+// verify replays the examples of s against h, returning false if any fail.
+func verify(w io.Writer, h http.Handler, s *schema.Schema, ignore []string) bool {
+	ok := true
+
+	for _, m := range s.Methods {
+		for i, e := range m.Examples {
+			name := e.Name
+			if name == "" {
+				name = fmt.Sprintf("example %d", i+1)
+			}
+
+			err := rpctest.Verify(h, m, e, ignore...)
+			if err != nil {
+				fmt.Fprintf(w, "FAIL %s/%s: %s\n", m.Name, name, err)
+				ok = false
+				continue
+			}
+
+			fmt.Fprintf(w, "ok   %s/%s\n", m.Name, name)
+		}
+	}
+
+	return ok
+}
+
+// remote is a handler forwarding requests to the server at url.
+type remote struct {
+	url   string
+	token string
+}
+
+// ServeHTTP implementation.
+func (h remote) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := http.NewRequest(r.Method, h.url+r.URL.Path, r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	req.Header = r.Header.Clone()
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer res.Body.Close()
+
+	for k, v := range res.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(res.StatusCode)
+	io.Copy(w, res.Body)
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
 	"github.com/newlix/rpc/schema"
 )
 
@@ -24,8 +25,25 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.Message)
 }
 
+// VersionError is returned by CheckVersion when the schema version of the
+// server differs from SchemaVersion.
+type VersionError struct {
+	Client string
+	Server string
+}
+
+// Error implementation.
+func (e VersionError) Error() string {
+	return fmt.Sprintf("schema version skew: client %s, server %s", e.Client, e.Server)
+}
+
 // call implementation.
 func call(client *http.Client, authToken, endpoint, method string, in, out interface{}) error {
+	return request(client, authToken, "POST", endpoint+"/"+method, in, out)
+}
+
+// request implementation.
+func request(client *http.Client, authToken, verb, url string, in, out interface{}) error {
 	var body io.Reader
 
 	// default client
@@ -43,8 +61,8 @@ func call(client *http.Client, authToken, endpoint, method string, in, out inter
 		body = &buf
 	}
 
-	// POST request
-	req, err := http.NewRequest("POST", endpoint+"/"+method, body)
+	// request
+	req, err := http.NewRequest(verb, url, body)
 	if err != nil {
 		return err
 	}
@@ -100,47 +118,100 @@ func Generate(w io.Writer, s *schema.Schema) error {
 	out(w, "  HTTPClient *http.Client\n")
 	out(w, "}\n\n")
 
-	for _, m := range s.Methods {
-		name := format.GoName(m.Name)
-		out(w, "// %s %s\n", name, m.Description)
-		out(w, "func (c *Client) %s(", name)
+	// version
+	out(w, "// SchemaVersion is the version of the schema the client was generated from.\n")
+	out(w, "const SchemaVersion = %q\n\n", s.Version)
+	out(w, "// CheckVersion fetches the schema served at GET /_schema, returning a\n")
+	out(w, "// VersionError when its version differs from SchemaVersion.\n")
+	out(w, "func (c *Client) CheckVersion() error {\n")
+	out(w, "  var out struct {\n")
+	out(w, "    Version string `json:\"version\"`\n")
+	out(w, "  }\n")
+	out(w, "  err := request(c.HTTPClient, c.AuthToken, \"GET\", c.URL+\"/_schema\", nil, &out)\n")
+	out(w, "  if err != nil {\n")
+	out(w, "    return err\n")
+	out(w, "  }\n")
+	out(w, "  if out.Version != SchemaVersion {\n")
+	out(w, "    return VersionError{Client: SchemaVersion, Server: out.Version}\n")
+	out(w, "  }\n")
+	out(w, "  return nil\n")
+	out(w, "}\n\n")
 
-		// input arg
-		if len(m.Inputs) > 0 {
-			out(w, "in %sInput", name)
-		}
-		out(w, ") ")
-
-		// output arg
-		if len(m.Outputs) > 0 {
-			out(w, "(*%sOutput, error) {\n", name)
-			out(w, "  var out %sOutput\n", name)
-		} else {
-			out(w, "error {\n")
-		}
+	// ungrouped methods
+	for _, m := range schemautil.GroupMethods(s, "") {
+		writeMethod(w, m, "Client", "c")
+	}
 
-		// return
-		out(w, "  return ")
-		if len(m.Outputs) > 0 {
-			out(w, "&out, ")
-		}
-		out(w, "call(c.HTTPClient, c.AuthToken, c.URL, \"%s\", ", m.Name)
-		if len(m.Inputs) > 0 {
-			out(w, "in, ")
-		} else {
-			out(w, "nil, ")
-		}
-		if len(m.Outputs) > 0 {
-			out(w, "&out)\n")
-		} else {
-			out(w, "nil)\n")
-		}
+	// groups
+	for _, g := range schemautil.Groups(s) {
+		name := format.GoName(g.Name) + "Client"
+		out(w, "// %s returns the client of the %s group.\n", format.GoName(g.Name), g.Name)
+		out(w, "//\n")
+		out(w, "// %s\n", g.Summary)
+		out(w, "func (c *Client) %s() *%s {\n", format.GoName(g.Name), name)
+		out(w, "  return &%s{client: c}\n", name)
+		out(w, "}\n\n")
 
-		// close
+		out(w, "// %s is the client of the %s group.\n", name, g.Name)
+		out(w, "//\n")
+		out(w, "// %s\n", g.Description)
+		out(w, "type %s struct {\n", name)
+		out(w, "  client *Client\n")
 		out(w, "}\n\n")
+
+		for _, m := range schemautil.GroupMethods(s, g.Name) {
+			writeMethod(w, m, name, "c.client")
+		}
 	}
 
 	out(w, "\n%s\n", call)
 
 	return nil
 }
+
+// writeMethod writes method m of the client type recv to w, where client
+// is the expression of the *Client making the call.
+func writeMethod(w io.Writer, m schema.Method, recv, client string) {
+	out := fmt.Fprintf
+	name := format.GoName(m.Name)
+	out(w, "// %s %s\n", name, m.Description)
+	if m.Deprecated != nil {
+		out(w, "//\n")
+		out(w, "// Deprecated: %s\n", m.Deprecated.Notice())
+	}
+	out(w, "func (c *%s) %s(", recv, name)
+
+	// input arg
+	if len(m.Inputs) > 0 {
+		out(w, "in %sInput", name)
+	}
+	out(w, ") ")
+
+	// output arg
+	if len(m.Outputs) > 0 {
+		out(w, "(*%sOutput, error) {\n", name)
+		out(w, "  var out %sOutput\n", name)
+	} else {
+		out(w, "error {\n")
+	}
+
+	// return
+	out(w, "  return ")
+	if len(m.Outputs) > 0 {
+		out(w, "&out, ")
+	}
+	out(w, "call(%s.HTTPClient, %s.AuthToken, %s.URL, \"%s\", ", client, client, client, m.Name)
+	if len(m.Inputs) > 0 {
+		out(w, "in, ")
+	} else {
+		out(w, "nil, ")
+	}
+	if len(m.Outputs) > 0 {
+		out(w, "&out)\n")
+	} else {
+		out(w, "nil)\n")
+	}
+
+	// close
+	out(w, "}\n\n")
+}
@@ -10,21 +10,68 @@ type Client struct {
   HTTPClient *http.Client
 }
 
+// SchemaVersion is the version of the schema the client was generated from.
+const SchemaVersion = "1.0.0"
+
+// CheckVersion fetches the schema served at GET /_schema, returning a
+// VersionError when its version differs from SchemaVersion.
+func (c *Client) CheckVersion() error {
+  var out struct {
+    Version string `json:"version"`
+  }
+  err := request(c.HTTPClient, c.AuthToken, "GET", c.URL+"/_schema", nil, &out)
+  if err != nil {
+    return err
+  }
+  if out.Version != SchemaVersion {
+    return VersionError{Client: SchemaVersion, Server: out.Version}
+  }
+  return nil
+}
+
+// GetStats returns statistics of the to-do list, for administrators.
+func (c *Client) GetStats() (*GetStatsOutput, error) {
+  var out GetStatsOutput
+  return &out, call(c.HTTPClient, c.AuthToken, c.URL, "get_stats", nil, &out)
+}
+
+// Items returns the client of the items group.
+//
+// Manage the items of the to-do list.
+func (c *Client) Items() *ItemsClient {
+  return &ItemsClient{client: c}
+}
+
+// ItemsClient is the client of the items group.
+//
+// The items group provides methods for adding, listing and removing to-do items.
+type ItemsClient struct {
+  client *Client
+}
+
 // AddItem adds an item to the list.
-func (c *Client) AddItem(in AddItemInput) error {
-  return call(c.HTTPClient, c.AuthToken, c.URL, "add_item", in, nil)
+func (c *ItemsClient) AddItem(in AddItemInput) error {
+  return call(c.client.HTTPClient, c.client.AuthToken, c.client.URL, "add_item", in, nil)
 }
 
 // GetItems returns all items in the list.
-func (c *Client) GetItems() (*GetItemsOutput, error) {
+func (c *ItemsClient) GetItems() (*GetItemsOutput, error) {
   var out GetItemsOutput
-  return &out, call(c.HTTPClient, c.AuthToken, c.URL, "get_items", nil, &out)
+  return &out, call(c.client.HTTPClient, c.client.AuthToken, c.client.URL, "get_items", nil, &out)
 }
 
 // RemoveItem removes an item from the to-do list.
-func (c *Client) RemoveItem(in RemoveItemInput) (*RemoveItemOutput, error) {
+//
+// Deprecated: Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+func (c *ItemsClient) RemoveItem(in RemoveItemInput) (*RemoveItemOutput, error) {
   var out RemoveItemOutput
-  return &out, call(c.HTTPClient, c.AuthToken, c.URL, "remove_item", in, &out)
+  return &out, call(c.client.HTTPClient, c.client.AuthToken, c.client.URL, "remove_item", in, &out)
+}
+
+// UpdateItem updates an item in the to-do list.
+func (c *ItemsClient) UpdateItem(in UpdateItemInput) (*UpdateItemOutput, error) {
+  var out UpdateItemOutput
+  return &out, call(c.client.HTTPClient, c.client.AuthToken, c.client.URL, "update_item", in, &out)
 }
 
 
@@ -44,8 +91,25 @@ func (e Error) Error() string {
 	return fmt.Sprintf("%s: %s", e.Type, e.Message)
 }
 
+// VersionError is returned by CheckVersion when the schema version of the
+// server differs from SchemaVersion.
+type VersionError struct {
+	Client string
+	Server string
+}
+
+// Error implementation.
+func (e VersionError) Error() string {
+	return fmt.Sprintf("schema version skew: client %s, server %s", e.Client, e.Server)
+}
+
 // call implementation.
 func call(client *http.Client, authToken, endpoint, method string, in, out interface{}) error {
+	return request(client, authToken, "POST", endpoint+"/"+method, in, out)
+}
+
+// request implementation.
+func request(client *http.Client, authToken, verb, url string, in, out interface{}) error {
 	var body io.Reader
 
 	// default client
@@ -63,8 +127,8 @@ func call(client *http.Client, authToken, endpoint, method string, in, out inter
 		body = &buf
 	}
 
-	// POST request
-	req, err := http.NewRequest("POST", endpoint+"/"+method, body)
+	// request
+	req, err := http.NewRequest(verb, url, body)
 	if err != nil {
 		return err
 	}
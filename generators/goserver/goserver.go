@@ -3,15 +3,48 @@ package goserver
 import (
 	"fmt"
 	"io"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
 	"github.com/newlix/rpc/schema"
 )
 
-// Generate writes the Go server implementations to w.
-func Generate(w io.Writer, s *schema.Schema, types string) error {
+// Read-only field policies.
+const (
+	// Ignore read-only fields in requests, which are absent from input types.
+	Ignore = "ignore"
+
+	// Reject requests setting read-only fields.
+	Reject = "reject"
+)
+
+// Generate writes the Go server implementations to w, handling read-only
+// fields in requests according to policy. When embed is the path of the
+// schema file relative to the package, such as "schema.json", the schema and
+// the files it includes or references are embedded and served by the GET
+// /_schema and /_methods routes.
+func Generate(w io.Writer, s *schema.Schema, types, policy, embed string) error {
+	switch policy {
+	case Ignore, Reject:
+	default:
+		return fmt.Errorf("unsupported read-only policy %q, must be one of: ignore, reject", policy)
+	}
+
+	// embedded schema
+	if embed != "" {
+		err := writeEmbed(w, s, embed)
+		if err != nil {
+			return fmt.Errorf("writing embedded schema: %w", err)
+		}
+	}
+
 	// router
-	err := writeRouter(w, s, types)
+	err := writeRouter(w, s, types, policy, embed)
 	if err != nil {
 		return fmt.Errorf("writing router: %w", err)
 	}
@@ -25,8 +58,53 @@ func Generate(w io.Writer, s *schema.Schema, types string) error {
 	return nil
 }
 
+// writeEmbed writes the variable embedding the schema file name to w,
+// along with the files of s it includes or references, and its public schema
+// built once on initialization.
+func writeEmbed(w io.Writer, s *schema.Schema, name string) error {
+	out := fmt.Fprintf
+
+	files, err := embedded(s, name)
+	if err != nil {
+		return err
+	}
+
+	out(w, "// schemaFS holds the schema file and the files it includes or references.\n")
+	out(w, "//\n")
+	out(w, "//go:embed %s\n", strings.Join(files, " "))
+	out(w, "var schemaFS embed.FS\n\n")
+	out(w, "// publicSchema is the schema served by the GET /_schema and /_methods routes.\n")
+	out(w, "var publicSchema = rpc.MustPublicSchema(schemaFS, %q)\n\n", name)
+	return nil
+}
+
+// embedded returns the paths of the files of s relative to the package, where
+// the root file is name. The files must not be outside of the package.
+func embedded(s *schema.Schema, name string) ([]string, error) {
+	if len(s.Files) == 0 {
+		return []string{name}, nil
+	}
+
+	root := filepath.Dir(s.Files[0])
+	var files []string
+	for _, f := range s.Files {
+		rel, err := filepath.Rel(root, f)
+		if err != nil {
+			return nil, err
+		}
+
+		p := path.Join(path.Dir(name), filepath.ToSlash(rel))
+		if p == ".." || strings.HasPrefix(p, "../") {
+			return nil, fmt.Errorf("%s is outside of the package", p)
+		}
+		files = append(files, p)
+	}
+
+	return files, nil
+}
+
 // writeRouter writes the routing implementation to w.
-func writeRouter(w io.Writer, s *schema.Schema, types string) error {
+func writeRouter(w io.Writer, s *schema.Schema, types, policy, embed string) error {
 	out := fmt.Fprintf
 	out(w, "// ServeHTTP implementation.\n")
 	out(w, "func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {\n")
@@ -34,6 +112,12 @@ func writeRouter(w io.Writer, s *schema.Schema, types string) error {
 	out(w, "    switch r.URL.Path {\n")
 	out(w, "      case \"/_health\":\n")
 	out(w, "        rpc.WriteHealth(w, s)\n")
+	if embed != "" {
+		out(w, "      case \"/_schema\":\n")
+		out(w, "        rpc.WriteSchema(w, publicSchema)\n")
+		out(w, "      case \"/_methods\":\n")
+		out(w, "        rpc.WriteMethods(w, publicSchema)\n")
+	}
 	out(w, "      default:\n")
 	out(w, "        rpc.WriteError(w, rpc.BadRequest(\"Invalid method\"))\n")
 	out(w, "    }\n")
@@ -46,10 +130,21 @@ func writeRouter(w io.Writer, s *schema.Schema, types string) error {
 	out(w, "    switch r.URL.Path {\n")
 	for _, m := range s.Methods {
 		out(w, "      case \"/%s\":\n", m.Name)
+		// deprecation
+		if d := m.Deprecated; d != nil {
+			out(w, "        w.Header().Set(\"Deprecation\", \"true\")\n")
+			if d.Sunset != "" {
+				out(w, "        w.Header().Set(\"Sunset\", %q)\n", sunset(d.Sunset))
+			}
+		}
 		// parse input
 		if len(m.Inputs) > 0 {
 			out(w, "        var in %s\n", format.GoInputType(types, m.Name))
-			out(w, "        err = rpc.ReadRequest(r, &in)\n")
+			if paths := schemautil.ReadOnlyPaths(s, m.Inputs); policy == Reject && len(paths) > 0 {
+				out(w, "        err = rpc.ReadRequestRejecting(r, &in, %s)\n", quote(paths))
+			} else {
+				out(w, "        err = rpc.ReadRequest(r, &in)\n")
+			}
 			out(w, "        if err != nil {\n")
 			out(w, "          break\n")
 			out(w, "        }\n")
@@ -81,6 +176,10 @@ func writeMethods(w io.Writer, s *schema.Schema, types string) error {
 	for _, m := range s.Methods {
 		out(w, "\n")
 		out(w, "// %s %s\n", format.JsName(m.Name), m.Description)
+		if m.Deprecated != nil {
+			out(w, "//\n")
+			out(w, "// Deprecated: %s\n", m.Deprecated.Notice())
+		}
 
 		// method signature
 		if len(m.Inputs) > 0 {
@@ -115,3 +214,18 @@ func writeMethods(w io.Writer, s *schema.Schema, types string) error {
 
 	return nil
 }
+
+// quote returns the Go string literals of values, separated by commas.
+func quote(values []string) string {
+	var v []string
+	for _, s := range values {
+		v = append(v, fmt.Sprintf("%q", s))
+	}
+	return strings.Join(v, ", ")
+}
+
+// sunset returns the Sunset header value of the date d, formatted as an HTTP date.
+func sunset(d string) string {
+	t, _ := time.Parse("2006-01-02", d)
+	return t.Format(http.TimeFormat)
+}
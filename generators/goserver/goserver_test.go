@@ -16,7 +16,7 @@ func TestGenerate_noTypes(t *testing.T) {
 	assert.NoError(t, err, "loading schema")
 
 	var act bytes.Buffer
-	err = goserver.Generate(&act, schema, "")
+	err = goserver.Generate(&act, schema, "", goserver.Ignore, "")
 	assert.NoError(t, err, "generating")
 
 	fixture.Assert(t, "todo_server_no_types.go", act.Bytes())
@@ -26,8 +26,60 @@ func TestGenerate_types(t *testing.T) {
 	assert.NoError(t, err, "loading schema")
 
 	var act bytes.Buffer
-	err = goserver.Generate(&act, schema, "api")
+	err = goserver.Generate(&act, schema, "api", goserver.Ignore, "")
 	assert.NoError(t, err, "generating")
 
 	fixture.Assert(t, "todo_server_types.go", act.Bytes())
 }
+
+func TestGenerate_reject(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = goserver.Generate(&act, schema, "api", goserver.Reject, "")
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_server_reject.go", act.Bytes())
+}
+
+func TestGenerate_embed(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = goserver.Generate(&act, schema, "api", goserver.Ignore, "schema.json")
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_server_embed.go", act.Bytes())
+}
+
+func TestGenerate_policy(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = goserver.Generate(&act, schema, "api", "drop", "")
+	assert.EqualError(t, err, `unsupported read-only policy "drop", must be one of: ignore, reject`)
+}
+
+func TestGenerate_embedIncludes(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/include/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	t.Run("within the package", func(t *testing.T) {
+		var act bytes.Buffer
+		err = goserver.Generate(&act, schema, "api", goserver.Ignore, "api/schema.json")
+		assert.NoError(t, err, "generating")
+		assert.Contains(t, act.String(), "//go:embed api/schema.json api/common.json api/methods/orders.json api/methods/products.json\n")
+	})
+
+	t.Run("outside of the package", func(t *testing.T) {
+		s := *schema
+		s.Files = []string{"api/schema.json", "common.json"}
+
+		var act bytes.Buffer
+		err = goserver.Generate(&act, &s, "api", goserver.Ignore, "schema.json")
+		assert.EqualError(t, err, `writing embedded schema: ../common.json is outside of the package`)
+	})
+}
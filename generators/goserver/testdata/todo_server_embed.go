@@ -0,0 +1,102 @@
+// schemaFS holds the schema file and the files it includes or references.
+//
+//go:embed schema.json
+var schemaFS embed.FS
+
+// publicSchema is the schema served by the GET /_schema and /_methods routes.
+var publicSchema = rpc.MustPublicSchema(schemaFS, "schema.json")
+
+// ServeHTTP implementation.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+  if r.Method == "GET" {
+    switch r.URL.Path {
+      case "/_health":
+        rpc.WriteHealth(w, s)
+      case "/_schema":
+        rpc.WriteSchema(w, publicSchema)
+      case "/_methods":
+        rpc.WriteMethods(w, publicSchema)
+      default:
+        rpc.WriteError(w, rpc.BadRequest("Invalid method"))
+    }
+    return
+  }
+
+  if r.Method == "POST" {
+    ctx := rpc.NewRequestContext(r.Context(), r)
+    var res interface{}
+    var err error
+    switch r.URL.Path {
+      case "/add_item":
+        var in api.AddItemInput
+        err = rpc.ReadRequest(r, &in)
+        if err != nil {
+          break
+        }
+        res, err = s.addItem(ctx, in)
+      case "/get_items":
+        res, err = s.getItems(ctx)
+      case "/get_stats":
+        res, err = s.getStats(ctx)
+      case "/remove_item":
+        w.Header().Set("Deprecation", "true")
+        w.Header().Set("Sunset", "Tue, 01 Jun 2027 00:00:00 GMT")
+        var in api.RemoveItemInput
+        err = rpc.ReadRequest(r, &in)
+        if err != nil {
+          break
+        }
+        res, err = s.removeItem(ctx, in)
+      case "/update_item":
+        var in api.UpdateItemInput
+        err = rpc.ReadRequest(r, &in)
+        if err != nil {
+          break
+        }
+        res, err = s.updateItem(ctx, in)
+      default:
+        err = rpc.BadRequest("Invalid method")
+    }
+
+    if err != nil {
+      rpc.WriteError(w, err)
+      return
+    }
+
+    rpc.WriteResponse(w, res)
+    return
+  }
+}
+
+// addItem adds an item to the list.
+func (s *Server) addItem(ctx context.Context, in api.AddItemInput) (interface{}, error) {
+  err := s.AddItem(ctx, in)
+  return nil, err
+}
+
+// getItems returns all items in the list.
+func (s *Server) getItems(ctx context.Context) (interface{}, error) {
+  res, err := s.GetItems(ctx)
+  return res, err
+}
+
+// getStats returns statistics of the to-do list, for administrators.
+func (s *Server) getStats(ctx context.Context) (interface{}, error) {
+  res, err := s.GetStats(ctx)
+  return res, err
+}
+
+// removeItem removes an item from the to-do list.
+//
+// Deprecated: Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+func (s *Server) removeItem(ctx context.Context, in api.RemoveItemInput) (interface{}, error) {
+  res, err := s.RemoveItem(ctx, in)
+  return res, err
+}
+
+// updateItem updates an item in the to-do list.
+func (s *Server) updateItem(ctx context.Context, in api.UpdateItemInput) (interface{}, error) {
+  res, err := s.UpdateItem(ctx, in)
+  return res, err
+}
+
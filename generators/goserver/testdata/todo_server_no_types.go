@@ -24,13 +24,24 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
         res, err = s.addItem(ctx, in)
       case "/get_items":
         res, err = s.getItems(ctx)
+      case "/get_stats":
+        res, err = s.getStats(ctx)
       case "/remove_item":
+        w.Header().Set("Deprecation", "true")
+        w.Header().Set("Sunset", "Tue, 01 Jun 2027 00:00:00 GMT")
         var in RemoveItemInput
         err = rpc.ReadRequest(r, &in)
         if err != nil {
           break
         }
         res, err = s.removeItem(ctx, in)
+      case "/update_item":
+        var in UpdateItemInput
+        err = rpc.ReadRequest(r, &in)
+        if err != nil {
+          break
+        }
+        res, err = s.updateItem(ctx, in)
       default:
         err = rpc.BadRequest("Invalid method")
     }
@@ -57,9 +68,23 @@ func (s *Server) getItems(ctx context.Context) (interface{}, error) {
   return res, err
 }
 
+// getStats returns statistics of the to-do list, for administrators.
+func (s *Server) getStats(ctx context.Context) (interface{}, error) {
+  res, err := s.GetStats(ctx)
+  return res, err
+}
+
 // removeItem removes an item from the to-do list.
+//
+// Deprecated: Set the item status to completed instead. Removal is scheduled for 2027-06-01.
 func (s *Server) removeItem(ctx context.Context, in RemoveItemInput) (interface{}, error) {
   res, err := s.RemoveItem(ctx, in)
   return res, err
 }
 
+// updateItem updates an item in the to-do list.
+func (s *Server) updateItem(ctx context.Context, in UpdateItemInput) (interface{}, error) {
+  res, err := s.UpdateItem(ctx, in)
+  return res, err
+}
+
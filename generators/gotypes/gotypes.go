@@ -3,6 +3,7 @@ package gotypes
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/newlix/rpc/internal/format"
@@ -10,8 +11,9 @@ import (
 	"github.com/newlix/rpc/schema"
 )
 
-// Generate writes the Go type implementations to w.
-func Generate(w io.Writer, s *schema.Schema) error {
+// Generate writes the Go type implementations to w, with optional validation methods.
+func Generate(w io.Writer, s *schema.Schema, validate bool) error {
+	s = schemautil.Inputs(s)
 	out := fmt.Fprintf
 
 	// default tags
@@ -19,12 +21,36 @@ func Generate(w io.Writer, s *schema.Schema) error {
 		s.Go.Tags = []string{"json"}
 	}
 
+	// enums
+	for _, t := range schemautil.Enums(s) {
+		if _, ok := schemautil.TypeMapping(schemautil.Go, t); ok {
+			continue
+		}
+		writeEnum(w, t, validate)
+	}
+
 	// types
 	for _, t := range s.TypesSlice() {
+		if _, ok := schemautil.TypeMapping(schemautil.Go, t); ok || t.IsEnum() {
+			continue
+		}
+		if t.IsUnion() {
+			writeUnion(w, s, t)
+			continue
+		}
 		out(w, "// %s %s\n", format.GoName(t.Name), t.Description)
+		writeDeprecated(w, "", t.Deprecated)
 		out(w, "type %s struct {\n", format.GoName(t.Name))
-		writeFields(w, s, t.Properties)
+		writeFields(w, s, schemautil.Owner(t), t.Properties)
 		out(w, "}\n\n")
+		if validate && schemautil.Validates(s, t.Properties) {
+			writeValidate(w, s, t.Name, format.GoName(t.Name), t.Properties)
+			out(w, "\n")
+		}
+		if hasDefaults(s, t.Properties) {
+			writeDefaults(w, s, format.GoName(t.Name), t.Properties)
+			out(w, "\n")
+		}
 	}
 
 	// methods
@@ -34,9 +60,18 @@ func Generate(w io.Writer, s *schema.Schema) error {
 		// inputs
 		if len(m.Inputs) > 0 {
 			out(w, "// %sInput params.\n", name)
+			writeDeprecated(w, "", m.Deprecated)
 			out(w, "type %sInput struct {\n", name)
-			writeFields(w, s, m.Inputs)
+			writeFields(w, s, m.Name+"_input", m.Inputs)
 			out(w, "}\n")
+			if validate && schemautil.Validates(s, m.Inputs) {
+				out(w, "\n")
+				writeValidate(w, s, m.Name+"_input", name+"Input", m.Inputs)
+			}
+			if hasDefaults(s, m.Inputs) {
+				out(w, "\n")
+				writeDefaults(w, s, name+"Input", m.Inputs)
+			}
 		}
 
 		// both
@@ -47,9 +82,14 @@ func Generate(w io.Writer, s *schema.Schema) error {
 		// outputs
 		if len(m.Outputs) > 0 {
 			out(w, "// %sOutput params.\n", name)
+			writeDeprecated(w, "", m.Deprecated)
 			out(w, "type %sOutput struct {\n", name)
-			writeFields(w, s, m.Outputs)
+			writeFields(w, s, m.Name+"_output", m.Outputs)
 			out(w, "}\n")
+			if hasDefaults(s, m.Outputs) {
+				out(w, "\n")
+				writeDefaults(w, s, name+"Output", m.Outputs)
+			}
 		}
 
 		out(w, "\n")
@@ -58,10 +98,280 @@ func Generate(w io.Writer, s *schema.Schema) error {
 	return nil
 }
 
+// hasDefaults returns true if any of the fields which are not mapped declare a default.
+func hasDefaults(s *schema.Schema, fields []schema.Field) bool {
+	for _, f := range fields {
+		if _, ok := schemautil.Mapping(s, schemautil.Go, f); !ok && f.Default != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// writeDefaults writes an UnmarshalJSON applying the defaults of the absent
+// fields of the struct name to w. The structs of nested fields apply their
+// own, as they are decoded by their UnmarshalJSON.
+func writeDefaults(w io.Writer, s *schema.Schema, name string, fields []schema.Field) {
+	out := fmt.Fprintf
+	out(w, "// UnmarshalJSON implementation, applying the defaults of absent fields.\n")
+	out(w, "func (v *%s) UnmarshalJSON(b []byte) error {\n", name)
+	out(w, "  type plain %s\n", name)
+	out(w, "  p := plain{\n")
+	for _, f := range fields {
+		if _, ok := schemautil.Mapping(s, schemautil.Go, f); !ok && f.Default != nil {
+			out(w, "    %s: %s,\n", format.GoName(f.Name), literal(f.Default))
+		}
+	}
+	out(w, "  }\n")
+	out(w, "  if err := json.Unmarshal(b, &p); err != nil {\n")
+	out(w, "    return err\n")
+	out(w, "  }\n")
+	out(w, "  *v = %s(p)\n", name)
+	out(w, "  return nil\n")
+	out(w, "}\n")
+}
+
+// literal returns the Go literal of the scalar default value v.
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// writeEnum writes the enum type t and its values to w, with an UnmarshalJSON
+// rejecting unknown values when validate is true. Otherwise unknown values are
+// decoded as-is, so clients keep working when servers add values.
+func writeEnum(w io.Writer, t schema.Type, validate bool) {
+	out := fmt.Fprintf
+	name := format.GoName(t.Name)
+
+	var values []string
+	for _, v := range t.Enum {
+		values = append(values, format.GoName(t.Name+"_"+v))
+	}
+
+	out(w, "// %s %s\n", name, t.Description)
+	writeDeprecated(w, "", t.Deprecated)
+	out(w, "type %s string\n\n", name)
+
+	out(w, "// %s values.\n", name)
+	out(w, "const (\n")
+	for i, v := range t.Enum {
+		out(w, "  %s %s = %q\n", values[i], name, v)
+	}
+	out(w, ")\n\n")
+
+	out(w, "// Valid returns true if v is a known %s value.\n", name)
+	out(w, "func (v %s) Valid() bool {\n", name)
+	out(w, "  switch v {\n")
+	out(w, "  case %s:\n", strings.Join(values, ", "))
+	out(w, "    return true\n")
+	out(w, "  default:\n")
+	out(w, "    return false\n")
+	out(w, "  }\n")
+	out(w, "}\n\n")
+
+	if !validate {
+		return
+	}
+
+	out(w, "// UnmarshalJSON implementation, rejecting unknown values. The empty string\n")
+	out(w, "// is accepted as the zero value of optional fields.\n")
+	out(w, "func (v *%s) UnmarshalJSON(b []byte) error {\n", name)
+	out(w, "  var s string\n")
+	out(w, "  if err := json.Unmarshal(b, &s); err != nil {\n")
+	out(w, "    return err\n")
+	out(w, "  }\n")
+	out(w, "  if s != \"\" && !%s(s).Valid() {\n", name)
+	out(w, "    return fmt.Errorf(\"invalid %s value %%q\", s)\n", name)
+	out(w, "  }\n")
+	out(w, "  *v = %s(s)\n", name)
+	out(w, "  return nil\n")
+	out(w, "}\n")
+}
+
+// writeUnion writes the union type t to w, a struct holding one of the
+// variants, with JSON marshaling driven by the discriminator.
+func writeUnion(w io.Writer, s *schema.Schema, t schema.Type) {
+	out := fmt.Fprintf
+	name := format.GoName(t.Name)
+	method := format.JsName(t.Name + "_" + t.Discriminator)
+	variants := schemautil.Variants(s, t)
+
+	var names []string
+	for _, v := range variants {
+		names = append(names, format.GoName(v.Name))
+	}
+
+	out(w, "// %s %s\n", name, t.Description)
+	writeDeprecated(w, "", t.Deprecated)
+	out(w, "type %s struct {\n", name)
+	out(w, "  // Value is one of %s.\n", strings.Join(names, ", "))
+	out(w, "  Value %sValue\n", name)
+	out(w, "}\n\n")
+
+	out(w, "// %sValue is implemented by the variants of %s.\n", name, name)
+	out(w, "type %sValue interface {\n", name)
+	out(w, "  %s() string\n", method)
+	out(w, "}\n\n")
+
+	for _, v := range variants {
+		out(w, "func (%s) %s() string { return %q }\n\n", format.GoName(v.Name), method, v.Name)
+	}
+
+	out(w, "// MarshalJSON implementation, adding the %q discriminator.\n", t.Discriminator)
+	out(w, "func (v %s) MarshalJSON() ([]byte, error) {\n", name)
+	out(w, "  if v.Value == nil {\n")
+	out(w, "    return []byte(\"null\"), nil\n")
+	out(w, "  }\n")
+	out(w, "  b, err := json.Marshal(v.Value)\n")
+	out(w, "  if err != nil {\n")
+	out(w, "    return nil, err\n")
+	out(w, "  }\n")
+	out(w, "  d, err := json.Marshal(map[string]string{%q: v.Value.%s()})\n", t.Discriminator, method)
+	out(w, "  if err != nil {\n")
+	out(w, "    return nil, err\n")
+	out(w, "  }\n")
+	out(w, "  if len(b) == 2 {\n")
+	out(w, "    return d, nil\n")
+	out(w, "  }\n")
+	out(w, "  return append(append(d[:len(d)-1], ','), b[1:]...), nil\n")
+	out(w, "}\n\n")
+
+	out(w, "// UnmarshalJSON implementation, decoding the variant named by the %q discriminator.\n", t.Discriminator)
+	out(w, "func (v *%s) UnmarshalJSON(b []byte) error {\n", name)
+	out(w, "  if string(b) == \"null\" {\n")
+	out(w, "    return nil\n")
+	out(w, "  }\n")
+	out(w, "  var d struct {\n")
+	out(w, "    %s string `json:%q`\n", format.GoName(t.Discriminator), t.Discriminator)
+	out(w, "  }\n")
+	out(w, "  if err := json.Unmarshal(b, &d); err != nil {\n")
+	out(w, "    return err\n")
+	out(w, "  }\n")
+	out(w, "  switch d.%s {\n", format.GoName(t.Discriminator))
+	for _, v := range variants {
+		out(w, "  case %q:\n", v.Name)
+		out(w, "    var value %s\n", format.GoName(v.Name))
+		out(w, "    if err := json.Unmarshal(b, &value); err != nil {\n")
+		out(w, "      return err\n")
+		out(w, "    }\n")
+		out(w, "    v.Value = value\n")
+	}
+	out(w, "  default:\n")
+	out(w, "    return fmt.Errorf(\"invalid %s %s %%q\", d.%s)\n", name, t.Discriminator, format.GoName(t.Discriminator))
+	out(w, "  }\n")
+	out(w, "  return nil\n")
+	out(w, "}\n")
+}
+
+// writeValidate writes the Validate method of the struct name to w, checking
+// the constraints of its fields and the structs they reference.
+func writeValidate(w io.Writer, s *schema.Schema, owner, name string, fields []schema.Field) {
+	out := fmt.Fprintf
+
+	// patterns
+	for _, f := range fields {
+		if schemautil.IsMapped(s, schemautil.Go, f) {
+			continue
+		}
+		for _, c := range schemautil.Checks(f) {
+			if c.Kind == schemautil.Pattern || c.Kind == schemautil.Format {
+				out(w, "var %s = regexp.MustCompile(%q)\n\n", patternName(owner, f, c), c.Pattern)
+			}
+		}
+	}
+
+	out(w, "// Validate implementation.\n")
+	out(w, "func (v %s) Validate() error {\n", name)
+	for _, f := range fields {
+		if schemautil.IsMapped(s, schemautil.Go, f) {
+			continue
+		}
+		field := "v." + format.GoName(f.Name)
+		nullable := schemautil.Nullable(s, f)
+		value := field
+		if nullable {
+			value = "*" + field
+		}
+
+		for _, c := range schemautil.Checks(f) {
+			var cond string
+			switch c.Kind {
+			case schemautil.MinLength:
+				cond = fmt.Sprintf("utf8.RuneCountInString(%s) < %d", value, c.Length)
+			case schemautil.MaxLength:
+				cond = fmt.Sprintf("utf8.RuneCountInString(%s) > %d", value, c.Length)
+			case schemautil.Pattern, schemautil.Format:
+				cond = fmt.Sprintf("!%s.MatchString(%s)", patternName(owner, f, c), value)
+			case schemautil.Minimum:
+				cond = fmt.Sprintf("%s < %s", value, c.Number)
+			case schemautil.Maximum:
+				cond = fmt.Sprintf("%s > %s", value, c.Number)
+			}
+
+			// optional fields are only checked when present
+			if nullable {
+				cond = fmt.Sprintf("%s != nil && %s", field, cond)
+			}
+
+			out(w, "  if %s {\n", cond)
+			out(w, "    return errors.New(%q)\n", f.Name+" "+c.Message)
+			out(w, "  }\n")
+		}
+
+		t, ok := schemautil.ValidatedRef(s, f)
+		if !ok || !schemautil.Validates(s, t.Properties) {
+			continue
+		}
+
+		if f.Type.Type == schema.Array {
+			out(w, "  for i, item := range %s {\n", field)
+			out(w, "    if err := item.Validate(); err != nil {\n")
+			out(w, "      return fmt.Errorf(\"%s[%%d]: %%w\", i, err)\n", f.Name)
+			out(w, "    }\n")
+			out(w, "  }\n")
+		} else if nullable {
+			out(w, "  if %s != nil {\n", field)
+			out(w, "    if err := %s.Validate(); err != nil {\n", field)
+			out(w, "      return fmt.Errorf(\"%s: %%w\", err)\n", f.Name)
+			out(w, "    }\n")
+			out(w, "  }\n")
+		} else {
+			out(w, "  if err := %s.Validate(); err != nil {\n", field)
+			out(w, "    return fmt.Errorf(\"%s: %%w\", err)\n", f.Name)
+			out(w, "  }\n")
+		}
+	}
+	out(w, "  return nil\n")
+	out(w, "}\n")
+}
+
+// patternName returns the name of the compiled pattern of check c.
+func patternName(owner string, f schema.Field, c schemautil.Check) string {
+	return format.JsName(owner + "_" + f.Name + "_" + c.Kind)
+}
+
+// writeDeprecated writes the deprecation notice of d to w, if any, as a
+// paragraph of the preceding comment.
+func writeDeprecated(w io.Writer, indent string, d *schema.Deprecation) {
+	if d == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "%s//\n", indent)
+	fmt.Fprintf(w, "%s// Deprecated: %s\n", indent, d.Notice())
+}
+
 // writeFields to writer.
-func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
+func writeFields(w io.Writer, s *schema.Schema, owner string, fields []schema.Field) {
 	for i, f := range fields {
-		writeField(w, s, f)
+		writeField(w, s, owner, f)
 		if i < len(fields)-1 {
 			fmt.Fprintf(w, "\n")
 		}
@@ -69,13 +379,35 @@ func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
 }
 
 // writeField to writer.
-func writeField(w io.Writer, s *schema.Schema, f schema.Field) {
+func writeField(w io.Writer, s *schema.Schema, owner string, f schema.Field) {
+	t := goType(s, f)
+	_, mapped := schemautil.Mapping(s, schemautil.Go, f)
+	if !mapped && schemautil.IsInlineEnum(f) {
+		t = format.GoName(schemautil.EnumName(owner, f))
+	}
+
+	// optional validated fields are nil when absent
+	if !mapped && schemautil.Nullable(s, f) {
+		t = "*" + t
+	}
+
 	fmt.Fprintf(w, "  // %s is %s%s\n", format.GoName(f.Name), f.Description, schemautil.FormatExtra(f))
-	fmt.Fprintf(w, "  %s %s %s\n", format.GoName(f.Name), goType(s, f), fieldTags(f, s.Go.Tags))
+	writeDeprecated(w, "  ", f.Deprecated)
+	fmt.Fprintf(w, "  %s %s %s\n", format.GoName(f.Name), t, fieldTags(f, s.Go.Tags))
+}
+
+// Type returns the Go type of field f, as used by the generated types.
+func Type(s *schema.Schema, f schema.Field) string {
+	return goType(s, f)
 }
 
 // goType returns a Go equivalent type for field f.
 func goType(s *schema.Schema, f schema.Field) string {
+	// mapping
+	if m, ok := schemautil.Mapping(s, schemautil.Go, f); ok {
+		return m.Type
+	}
+
 	// ref
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
@@ -95,11 +427,12 @@ func goType(s *schema.Schema, f schema.Field) string {
 	case schema.Timestamp:
 		return "time.Time"
 	case schema.Object:
-		return "map[string]interface{}"
+		if !f.HasValues() {
+			return "map[string]interface{}"
+		}
+		return "map[string]" + goType(s, f.Values.Field())
 	case schema.Array:
-		return "[]" + goType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		})
+		return "[]" + goType(s, f.Items.Field())
 	default:
 		panic("unhandled type")
 	}
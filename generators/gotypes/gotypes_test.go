@@ -16,8 +16,41 @@ func TestGenerate_noValidate(t *testing.T) {
 	assert.NoError(t, err, "loading schema")
 
 	var act bytes.Buffer
-	err = gotypes.Generate(&act, schema)
+	err = gotypes.Generate(&act, schema, false)
 	assert.NoError(t, err, "generating")
 
 	fixture.Assert(t, "todo_types_no_validate.go", act.Bytes())
 }
+
+func TestGenerate_validate(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = gotypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_types_validate.go", act.Bytes())
+}
+
+func TestGenerate_mappings(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/mapping.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = gotypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "mapping_types.go", act.Bytes())
+}
+
+func TestGenerate_optional(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/optional.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = gotypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "optional_types.go", act.Bytes())
+}
@@ -0,0 +1,35 @@
+// Product is a product.
+type Product struct {
+  // Colors is the colors available.
+  Colors []color.RGBA `json:"colors"`
+
+  // Discounts is the discounted prices by code.
+  Discounts map[string]decimal.Decimal `json:"discounts"`
+
+  // Name is the name. Must be at least 1 character long.
+  Name *string `json:"name"`
+
+  // Price is the price. Must be at least 0.
+  Price decimal.Decimal `json:"price"`
+}
+
+// Validate implementation.
+func (v Product) Validate() error {
+  if v.Name != nil && utf8.RuneCountInString(*v.Name) < 1 {
+    return errors.New("name must be at least 1 character long")
+  }
+  return nil
+}
+
+// GetProductInput params.
+type GetProductInput struct {
+  // ID is the product id. This field is required.
+  ID uuid.UUID `json:"id"`
+}
+
+// GetProductOutput params.
+type GetProductOutput struct {
+  // Product is the product.
+  Product *Product `json:"product"`
+}
+
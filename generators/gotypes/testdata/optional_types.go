@@ -0,0 +1,36 @@
+// Address is a postal address.
+type Address struct {
+  // Street is the street. This field is required. Must be at least 1 character long.
+  Street string `json:"street"`
+}
+
+// Validate implementation.
+func (v Address) Validate() error {
+  if utf8.RuneCountInString(v.Street) < 1 {
+    return errors.New("street must be at least 1 character long")
+  }
+  return nil
+}
+
+// ShipOrderInput params.
+type ShipOrderInput struct {
+  // Address is the delivery address, defaulting to the account address.
+  Address *Address `json:"address"`
+
+  // Quantity is the number of parcels. Must be at least 1.
+  Quantity *int `json:"quantity"`
+}
+
+// Validate implementation.
+func (v ShipOrderInput) Validate() error {
+  if v.Address != nil {
+    if err := v.Address.Validate(); err != nil {
+      return fmt.Errorf("address: %w", err)
+    }
+  }
+  if v.Quantity != nil && *v.Quantity < 1 {
+    return errors.New("quantity must be at least 1")
+  }
+  return nil
+}
+
@@ -0,0 +1,360 @@
+// ItemStatus is the status of the to-do item.
+type ItemStatus string
+
+// ItemStatus values.
+const (
+  ItemStatusPending ItemStatus = "pending"
+  ItemStatusCompleted ItemStatus = "completed"
+)
+
+// Valid returns true if v is a known ItemStatus value.
+func (v ItemStatus) Valid() bool {
+  switch v {
+  case ItemStatusPending, ItemStatusCompleted:
+    return true
+  default:
+    return false
+  }
+}
+
+// UnmarshalJSON implementation, rejecting unknown values. The empty string
+// is accepted as the zero value of optional fields.
+func (v *ItemStatus) UnmarshalJSON(b []byte) error {
+  var s string
+  if err := json.Unmarshal(b, &s); err != nil {
+    return err
+  }
+  if s != "" && !ItemStatus(s).Valid() {
+    return fmt.Errorf("invalid ItemStatus value %q", s)
+  }
+  *v = ItemStatus(s)
+  return nil
+}
+// Priority is the priority of a to-do item.
+type Priority string
+
+// Priority values.
+const (
+  PriorityLow Priority = "low"
+  PriorityNormal Priority = "normal"
+  PriorityHigh Priority = "high"
+)
+
+// Valid returns true if v is a known Priority value.
+func (v Priority) Valid() bool {
+  switch v {
+  case PriorityLow, PriorityNormal, PriorityHigh:
+    return true
+  default:
+    return false
+  }
+}
+
+// UnmarshalJSON implementation, rejecting unknown values. The empty string
+// is accepted as the zero value of optional fields.
+func (v *Priority) UnmarshalJSON(b []byte) error {
+  var s string
+  if err := json.Unmarshal(b, &s); err != nil {
+    return err
+  }
+  if s != "" && !Priority(s).Valid() {
+    return fmt.Errorf("invalid Priority value %q", s)
+  }
+  *v = Priority(s)
+  return nil
+}
+// Item is a to-do item.
+type Item struct {
+  // CreatedAt is the time the to-do item was created. This field is read-only.
+  CreatedAt time.Time `json:"created_at"`
+
+  // Done is whether the to-do item is done.
+  //
+  // Deprecated: Use status instead.
+  Done bool `json:"done"`
+
+  // ID is the id of the item. This field is read-only.
+  ID int `json:"id"`
+
+  // Labels is the labels of the to-do item, keyed by name.
+  Labels map[string]string `json:"labels"`
+
+  // Priority is the priority of the to-do item. Defaults to "normal".
+  Priority Priority `json:"priority"`
+
+  // Reminder is the reminder of the to-do item.
+  Reminder Reminder `json:"reminder"`
+
+  // Status is the status of the to-do item. Must be one of: "pending", "completed".
+  Status ItemStatus `json:"status"`
+
+  // Text is the to-do item text. This field is required. Must be at most 200 characters long.
+  Text string `json:"text"`
+
+  // URL is the link of the to-do item. Must be a valid URI.
+  URL *string `json:"url"`
+}
+
+var itemUrlFormat = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9+.-]*:\\S+$")
+
+// Validate implementation.
+func (v Item) Validate() error {
+  if utf8.RuneCountInString(v.Text) > 200 {
+    return errors.New("text must be at most 200 characters long")
+  }
+  if v.URL != nil && !itemUrlFormat.MatchString(*v.URL) {
+    return errors.New("url must be a valid URI")
+  }
+  return nil
+}
+
+// UnmarshalJSON implementation, applying the defaults of absent fields.
+func (v *Item) UnmarshalJSON(b []byte) error {
+  type plain Item
+  p := plain{
+    Priority: "normal",
+  }
+  if err := json.Unmarshal(b, &p); err != nil {
+    return err
+  }
+  *v = Item(p)
+  return nil
+}
+
+// ItemInput is a to-do item. Read-only fields are omitted.
+type ItemInput struct {
+  // Done is whether the to-do item is done.
+  //
+  // Deprecated: Use status instead.
+  Done bool `json:"done"`
+
+  // Labels is the labels of the to-do item, keyed by name.
+  Labels map[string]string `json:"labels"`
+
+  // Priority is the priority of the to-do item. Defaults to "normal".
+  Priority Priority `json:"priority"`
+
+  // Reminder is the reminder of the to-do item.
+  Reminder Reminder `json:"reminder"`
+
+  // Status is the status of the to-do item. Must be one of: "pending", "completed".
+  Status ItemStatus `json:"status"`
+
+  // Text is the to-do item text. This field is required. Must be at most 200 characters long.
+  Text string `json:"text"`
+
+  // URL is the link of the to-do item. Must be a valid URI.
+  URL *string `json:"url"`
+}
+
+var itemInputUrlFormat = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9+.-]*:\\S+$")
+
+// Validate implementation.
+func (v ItemInput) Validate() error {
+  if utf8.RuneCountInString(v.Text) > 200 {
+    return errors.New("text must be at most 200 characters long")
+  }
+  if v.URL != nil && !itemInputUrlFormat.MatchString(*v.URL) {
+    return errors.New("url must be a valid URI")
+  }
+  return nil
+}
+
+// UnmarshalJSON implementation, applying the defaults of absent fields.
+func (v *ItemInput) UnmarshalJSON(b []byte) error {
+  type plain ItemInput
+  p := plain{
+    Priority: "normal",
+  }
+  if err := json.Unmarshal(b, &p); err != nil {
+    return err
+  }
+  *v = ItemInput(p)
+  return nil
+}
+
+// LocationReminder is a reminder when arriving at a location.
+type LocationReminder struct {
+  // Latitude is the latitude of the location. This field is required.
+  Latitude float64 `json:"latitude"`
+
+  // Longitude is the longitude of the location. This field is required.
+  Longitude float64 `json:"longitude"`
+}
+
+// Reminder is a reminder for a to-do item.
+type Reminder struct {
+  // Value is one of TimeReminder, LocationReminder.
+  Value ReminderValue
+}
+
+// ReminderValue is implemented by the variants of Reminder.
+type ReminderValue interface {
+  reminderType() string
+}
+
+func (TimeReminder) reminderType() string { return "time_reminder" }
+
+func (LocationReminder) reminderType() string { return "location_reminder" }
+
+// MarshalJSON implementation, adding the "type" discriminator.
+func (v Reminder) MarshalJSON() ([]byte, error) {
+  if v.Value == nil {
+    return []byte("null"), nil
+  }
+  b, err := json.Marshal(v.Value)
+  if err != nil {
+    return nil, err
+  }
+  d, err := json.Marshal(map[string]string{"type": v.Value.reminderType()})
+  if err != nil {
+    return nil, err
+  }
+  if len(b) == 2 {
+    return d, nil
+  }
+  return append(append(d[:len(d)-1], ','), b[1:]...), nil
+}
+
+// UnmarshalJSON implementation, decoding the variant named by the "type" discriminator.
+func (v *Reminder) UnmarshalJSON(b []byte) error {
+  if string(b) == "null" {
+    return nil
+  }
+  var d struct {
+    Type string `json:"type"`
+  }
+  if err := json.Unmarshal(b, &d); err != nil {
+    return err
+  }
+  switch d.Type {
+  case "time_reminder":
+    var value TimeReminder
+    if err := json.Unmarshal(b, &value); err != nil {
+      return err
+    }
+    v.Value = value
+  case "location_reminder":
+    var value LocationReminder
+    if err := json.Unmarshal(b, &value); err != nil {
+      return err
+    }
+    v.Value = value
+  default:
+    return fmt.Errorf("invalid Reminder type %q", d.Type)
+  }
+  return nil
+}
+// Stats is the statistics of a to-do list.
+type Stats struct {
+  // Completed is the number of completed items.
+  Completed int `json:"completed"`
+
+  // Total is the number of items.
+  Total int `json:"total"`
+}
+
+// TimeReminder is a reminder at a point in time.
+type TimeReminder struct {
+  // At is the time to remind at. This field is required.
+  At time.Time `json:"at"`
+}
+
+// AddItemInput params.
+type AddItemInput struct {
+  // Item is the item to add. This field is required. Must be at least 1 character long. Must be at most 200 characters long.
+  Item string `json:"item"`
+
+  // Priority is the priority of the item. Defaults to "normal".
+  Priority Priority `json:"priority"`
+}
+
+// Validate implementation.
+func (v AddItemInput) Validate() error {
+  if utf8.RuneCountInString(v.Item) < 1 {
+    return errors.New("item must be at least 1 character long")
+  }
+  if utf8.RuneCountInString(v.Item) > 200 {
+    return errors.New("item must be at most 200 characters long")
+  }
+  return nil
+}
+
+// UnmarshalJSON implementation, applying the defaults of absent fields.
+func (v *AddItemInput) UnmarshalJSON(b []byte) error {
+  type plain AddItemInput
+  p := plain{
+    Priority: "normal",
+  }
+  if err := json.Unmarshal(b, &p); err != nil {
+    return err
+  }
+  *v = AddItemInput(p)
+  return nil
+}
+
+// GetItemsOutput params.
+type GetItemsOutput struct {
+  // Items is the list of to-do items.
+  Items []Item `json:"items"`
+
+  // Lists is the to-do items grouped by list name.
+  Lists map[string][]Item `json:"lists"`
+}
+
+// GetStatsOutput params.
+type GetStatsOutput struct {
+  // Stats is the statistics.
+  Stats Stats `json:"stats"`
+}
+
+// RemoveItemInput params.
+//
+// Deprecated: Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+type RemoveItemInput struct {
+  // ID is the id of the item to remove. Must be at least 1.
+  ID *int `json:"id"`
+}
+
+// Validate implementation.
+func (v RemoveItemInput) Validate() error {
+  if v.ID != nil && *v.ID < 1 {
+    return errors.New("id must be at least 1")
+  }
+  return nil
+}
+
+// RemoveItemOutput params.
+//
+// Deprecated: Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+type RemoveItemOutput struct {
+  // Item is the item removed.
+  Item *Item `json:"item"`
+}
+
+// UpdateItemInput params.
+type UpdateItemInput struct {
+  // ID is the id of the item to update. This field is required. Must be at least 1.
+  ID int `json:"id"`
+
+  // Item is the updated item. This field is required.
+  Item ItemInput `json:"item"`
+}
+
+// Validate implementation.
+func (v UpdateItemInput) Validate() error {
+  if v.ID < 1 {
+    return errors.New("id must be at least 1")
+  }
+  if err := v.Item.Validate(); err != nil {
+    return fmt.Errorf("item: %w", err)
+  }
+  return nil
+}
+
+// UpdateItemOutput params.
+type UpdateItemOutput struct {
+  // Item is the item updated.
+  Item *Item `json:"item"`
+}
+
@@ -3,9 +3,11 @@ package kotlinclient
 import (
 	"fmt"
 	"io"
+	"strings"
 
-	"github.com/newlix/rpc/schema"
 	"github.com/iancoleman/strcase"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
 )
 
 var start = `
@@ -32,7 +34,7 @@ private data class ResponseError(val type: String, val message: String)
 // RPC is the API client.
 // url is the required API endpoint address.
 class RPC(val endpoint: String) {
-    val decoder = Json { ignoreUnknownKeys = true }
+    val decoder = Json { ignoreUnknownKeys = true; coerceInputValues = true }
 
     // AuthToken is an optional authentication token.
     var authToken: String? = null
@@ -40,7 +42,7 @@ class RPC(val endpoint: String) {
     // client is used for making requests, defaulting to URLSession.shared.
     val client = OkHttpClient()
 
-    private suspend fun call(
+    internal suspend fun call(
         method: String, input: String
     ): String {
         return withContext(Dispatchers.IO) {
@@ -77,68 +79,102 @@ func Generate(w io.Writer, s *schema.Schema) error {
 	// w = os.Stderr
 	out(w, start)
 
-	for _, m := range s.Methods {
+	// ungrouped methods
+	writeMethods(w, schemautil.GroupMethods(s, ""), "")
+
+	// groups
+	for _, g := range schemautil.Groups(s) {
+		out(w, "    // %s is the client of the %s group.\n", strcase.ToLowerCamel(g.Name), g.Name)
+		out(w, "    // %s\n", g.Summary)
+		out(w, "    val %s = %sRPC(this)\n\n", strcase.ToLowerCamel(g.Name), strcase.ToCamel(g.Name))
+	}
+
+	out(w, "}\n")
+
+	for _, g := range schemautil.Groups(s) {
+		out(w, "\n")
+		out(w, "// %sRPC is the client of the %s group.\n", strcase.ToCamel(g.Name), g.Name)
+		out(w, "// %s\n", g.Description)
+		out(w, "class %sRPC(private val rpc: RPC) {\n", strcase.ToCamel(g.Name))
+		writeMethods(w, schemautil.GroupMethods(s, g.Name), "rpc.")
+		out(w, "}\n")
+	}
+
+	return nil
+}
+
+// writeMethods writes methods to w, using the decoder and call
+// function of the client expression prefix.
+func writeMethods(w io.Writer, methods []schema.Method, prefix string) {
+	out := fmt.Fprintf
+	for _, m := range methods {
 		name := strcase.ToLowerCamel(m.Name)
 		out(w, "    // %s %s\n", name, m.Description)
+		if d := m.Deprecated; d != nil {
+			out(w, "    @Deprecated(\"%s\")\n", escape(d.Notice()))
+		}
 
 		if len(m.Inputs) > 0 && len(m.Outputs) == 0 {
-			writeInputOnlyMethod(w, m)
+			writeInputOnlyMethod(w, m, prefix)
 			continue
 		}
 
 		if len(m.Inputs) == 0 && len(m.Outputs) > 0 {
-			writeOutputOnlyMethod(w, m)
+			writeOutputOnlyMethod(w, m, prefix)
 			continue
 		}
 
 		if len(m.Inputs) > 0 && len(m.Outputs) > 0 {
-			writeMethod(w, m)
+			writeMethod(w, m, prefix)
 			continue
 		}
 	}
+}
 
-	out(w, "}\n")
-
-	return nil
+// escape returns s escaped for use in a string literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`).Replace(s)
 }
 
-func writeInputOnlyMethod(w io.Writer, m schema.Method) {
+func writeInputOnlyMethod(w io.Writer, m schema.Method, prefix string) {
 	camel := strcase.ToCamel(m.Name)
 	lcamel := strcase.ToLowerCamel(m.Name)
 	template := `    suspend fun %s(input: %sInput) {
-        val s = decoder.encodeToString(input)
-        call(method = "%s", input = s)
+        (input as? Validatable)?.validate()
+        val s = %[3]sdecoder.encodeToString(input)
+        %[3]scall(method = "%[4]s", input = s)
     }
 
 `
-	fmt.Fprintf(w, template, lcamel, camel, m.Name)
+	fmt.Fprintf(w, template, lcamel, camel, prefix, m.Name)
 
 }
 
-func writeOutputOnlyMethod(w io.Writer, m schema.Method) {
+func writeOutputOnlyMethod(w io.Writer, m schema.Method, prefix string) {
 	camel := strcase.ToCamel(m.Name)
 	lcamel := strcase.ToLowerCamel(m.Name)
 	template := `    suspend fun %s(): %sOutput {
-        val out = call(method = "%s", input = "")
-        return decoder.decodeFromString(out)
+        val out = %[3]scall(method = "%[4]s", input = "")
+        return %[3]sdecoder.decodeFromString(out)
     }
 
 `
-	fmt.Fprintf(w, template, lcamel, camel, m.Name)
+	fmt.Fprintf(w, template, lcamel, camel, prefix, m.Name)
 
 }
 
-func writeMethod(w io.Writer, m schema.Method) {
+func writeMethod(w io.Writer, m schema.Method, prefix string) {
 	camel := strcase.ToCamel(m.Name)
 	lcamel := strcase.ToLowerCamel(m.Name)
 	template := `    suspend fun %s(
         input: %sInput
     ): %sOutput {
-        val s = decoder.encodeToString(input)
-        val out = call(method = "%s", input = s)
-        return decoder.decodeFromString(out)
+        (input as? Validatable)?.validate()
+        val s = %[4]sdecoder.encodeToString(input)
+        val out = %[4]scall(method = "%[5]s", input = s)
+        return %[4]sdecoder.decodeFromString(out)
     }
 `
-	fmt.Fprintf(w, template, lcamel, camel, camel, m.Name)
+	fmt.Fprintf(w, template, lcamel, camel, camel, prefix, m.Name)
 
 }
@@ -22,7 +22,7 @@ private data class ResponseError(val type: String, val message: String)
 // RPC is the API client.
 // url is the required API endpoint address.
 class RPC(val endpoint: String) {
-    val decoder = Json { ignoreUnknownKeys = true }
+    val decoder = Json { ignoreUnknownKeys = true; coerceInputValues = true }
 
     // AuthToken is an optional authentication token.
     var authToken: String? = null
@@ -30,7 +30,7 @@ class RPC(val endpoint: String) {
     // client is used for making requests, defaulting to URLSession.shared.
     val client = OkHttpClient()
 
-    private suspend fun call(
+    internal suspend fun call(
         method: String, input: String
     ): String {
         return withContext(Dispatchers.IO) {
@@ -59,24 +59,51 @@ class RPC(val endpoint: String) {
             }
         }
     }
+    // getStats returns statistics of the to-do list, for administrators.
+    suspend fun getStats(): GetStatsOutput {
+        val out = call(method = "get_stats", input = "")
+        return decoder.decodeFromString(out)
+    }
+
+    // items is the client of the items group.
+    // Manage the items of the to-do list.
+    val items = ItemsRPC(this)
+
+}
+
+// ItemsRPC is the client of the items group.
+// The items group provides methods for adding, listing and removing to-do items.
+class ItemsRPC(private val rpc: RPC) {
     // addItem adds an item to the list.
     suspend fun addItem(input: AddItemInput) {
-        val s = decoder.encodeToString(input)
-        call(method = "add_item", input = s)
+        (input as? Validatable)?.validate()
+        val s = rpc.decoder.encodeToString(input)
+        rpc.call(method = "add_item", input = s)
     }
 
     // getItems returns all items in the list.
     suspend fun getItems(): GetItemsOutput {
-        val out = call(method = "get_items", input = "")
-        return decoder.decodeFromString(out)
+        val out = rpc.call(method = "get_items", input = "")
+        return rpc.decoder.decodeFromString(out)
     }
 
     // removeItem removes an item from the to-do list.
+    @Deprecated("Set the item status to completed instead. Removal is scheduled for 2027-06-01.")
     suspend fun removeItem(
         input: RemoveItemInput
     ): RemoveItemOutput {
-        val s = decoder.encodeToString(input)
-        val out = call(method = "remove_item", input = s)
-        return decoder.decodeFromString(out)
+        (input as? Validatable)?.validate()
+        val s = rpc.decoder.encodeToString(input)
+        val out = rpc.call(method = "remove_item", input = s)
+        return rpc.decoder.decodeFromString(out)
+    }
+    // updateItem updates an item in the to-do list.
+    suspend fun updateItem(
+        input: UpdateItemInput
+    ): UpdateItemOutput {
+        (input as? Validatable)?.validate()
+        val s = rpc.decoder.encodeToString(input)
+        val out = rpc.call(method = "update_item", input = s)
+        return rpc.decoder.decodeFromString(out)
     }
 }
@@ -3,6 +3,10 @@ package kotlintypes
 import (
 	"fmt"
 	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/newlix/rpc/internal/schemautil"
 	"github.com/newlix/rpc/schema"
@@ -11,21 +15,62 @@ import (
 
 // Generate writes the Go type implementations to w, with optional validation methods.
 func Generate(w io.Writer, s *schema.Schema, validate bool) error {
+	s = schemautil.Inputs(s)
 	out := fmt.Fprintf
 
-	out(w, "import kotlinx.serialization.SerialName\n")
-	out(w, "import kotlinx.serialization.Serializable\n")
+	imports := schemautil.Imports(s, schemautil.Kotlin)
+	imports = append(imports, "kotlinx.serialization.SerialName", "kotlinx.serialization.Serializable")
+	if hasUnions(s) {
+		imports = append(imports, "kotlinx.serialization.ExperimentalSerializationApi", "kotlinx.serialization.json.JsonClassDiscriminator")
+	}
+	sort.Strings(imports)
+	for _, v := range imports {
+		out(w, "import %s\n", v)
+	}
 	out(w, "\n")
 
+	out(w, "/**\n * Validatable is implemented by params with client-side validation.\n */\n")
+	out(w, "interface Validatable {\n")
+	out(w, "    fun validate()\n")
+	out(w, "}\n\n")
+	out(w, "/**\n * ValidationError is an error of params failing validation.\n */\n")
+	out(w, "class ValidationError(message: String) : Exception(message)\n\n")
+
+	// enums
+	for _, t := range schemautil.Enums(s) {
+		if _, ok := schemautil.TypeMapping(schemautil.Kotlin, t); ok {
+			continue
+		}
+		writeEnum(w, t)
+	}
+
 	// types
 	for _, t := range s.TypesSlice() {
+		if _, ok := schemautil.TypeMapping(schemautil.Kotlin, t); ok || t.IsEnum() {
+			continue
+		}
+		if t.IsUnion() {
+			writeUnion(w, t)
+			continue
+		}
+
+		// variants are serialized with their type name
+		var unions []string
+		for _, u := range schemautil.Unions(s, t) {
+			unions = append(unions, strcase.ToCamel(u.Name))
+		}
+
 		out(w, "/**\n * %s %s\n", strcase.ToCamel(t.Name), t.Description)
 		writeFieldsDoc(w, s, t.Properties)
 		out(w, " */\n")
+		writeDeprecated(w, t.Deprecated)
 		out(w, "@Serializable\n")
+		if len(unions) > 0 {
+			out(w, "@SerialName(\"%s\")\n", t.Name)
+		}
 		out(w, "data class %s(\n", strcase.ToCamel(t.Name))
-		writeFields(w, s, t.Properties)
-		out(w, "\n)\n\n")
+		writeFields(w, s, schemautil.Owner(t), t.Properties)
+		writeEnd(w, s, unions, validate, t.Properties)
 	}
 
 	// methods
@@ -36,10 +81,11 @@ func Generate(w io.Writer, s *schema.Schema, validate bool) error {
 			out(w, "/**\n * %s input params.\n", strcase.ToLowerCamel(m.Name))
 			writeFieldsDoc(w, s, m.Inputs)
 			out(w, " */\n")
+			writeDeprecated(w, m.Deprecated)
 			out(w, "@Serializable\n")
 			out(w, "data class %sInput(\n", strcase.ToCamel(m.Name))
-			writeFields(w, s, m.Inputs)
-			out(w, "\n)\n\n")
+			writeFields(w, s, m.Name+"_input", m.Inputs)
+			writeEnd(w, s, nil, validate, m.Inputs)
 		}
 
 		// outputs
@@ -47,9 +93,10 @@ func Generate(w io.Writer, s *schema.Schema, validate bool) error {
 			out(w, "/**\n * %s output params.\n", strcase.ToLowerCamel(m.Name))
 			writeFieldsDoc(w, s, m.Outputs)
 			out(w, " */\n")
+			writeDeprecated(w, m.Deprecated)
 			out(w, "@Serializable\n")
 			out(w, "data class %sOutput(\n", strcase.ToCamel(m.Name))
-			writeFields(w, s, m.Outputs)
+			writeFields(w, s, m.Name+"_output", m.Outputs)
 			out(w, "\n)\n\n")
 		}
 
@@ -58,6 +105,141 @@ func Generate(w io.Writer, s *schema.Schema, validate bool) error {
 	return nil
 }
 
+// writeEnd writes the end of a data class to w, implementing the given
+// interfaces and Validatable when its fields are validated.
+func writeEnd(w io.Writer, s *schema.Schema, interfaces []string, validate bool, fields []schema.Field) {
+	validate = validate && schemautil.Validates(s, fields)
+	if validate {
+		interfaces = append(interfaces, "Validatable")
+	}
+
+	if len(interfaces) == 0 {
+		fmt.Fprintf(w, "\n)\n\n")
+		return
+	}
+
+	if !validate {
+		fmt.Fprintf(w, "\n) : %s\n\n", strings.Join(interfaces, ", "))
+		return
+	}
+
+	fmt.Fprintf(w, "\n) : %s {\n", strings.Join(interfaces, ", "))
+	writeValidate(w, s, fields)
+	fmt.Fprintf(w, "}\n\n")
+}
+
+// writeValidate writes the validate method to w, checking the
+// constraints of the fields and the data classes they reference.
+func writeValidate(w io.Writer, s *schema.Schema, fields []schema.Field) {
+	out := fmt.Fprintf
+	out(w, "    override fun validate() {\n")
+	for _, f := range fields {
+		if schemautil.IsMapped(s, schemautil.Kotlin, f) {
+			continue
+		}
+		field := strcase.ToLowerCamel(f.Name)
+		nullable := schemautil.Nullable(s, f)
+		value := field
+		if nullable {
+			value = field + "!!"
+		}
+
+		for _, c := range schemautil.Checks(f) {
+			var cond string
+			switch c.Kind {
+			case schemautil.MinLength:
+				cond = fmt.Sprintf("%s.codePointCount(0, %s.length) < %d", value, value, c.Length)
+			case schemautil.MaxLength:
+				cond = fmt.Sprintf("%s.codePointCount(0, %s.length) > %d", value, value, c.Length)
+			case schemautil.Pattern, schemautil.Format:
+				pattern := strings.ReplaceAll(c.Pattern, "$", "${'$'}")
+				cond = fmt.Sprintf("!Regex(\"\"\"%s\"\"\").matches(%s)", pattern, value)
+			case schemautil.Minimum:
+				cond = fmt.Sprintf("%s < %s", value, c.Number)
+			case schemautil.Maximum:
+				cond = fmt.Sprintf("%s > %s", value, c.Number)
+			}
+
+			// optional fields are only checked when present
+			if nullable {
+				cond = fmt.Sprintf("%s != null && %s", field, cond)
+			}
+
+			out(w, "        if (%s) {\n", cond)
+			out(w, "            throw ValidationError(\"%s\")\n", escape(f.Name+" "+c.Message))
+			out(w, "        }\n")
+		}
+
+		t, ok := schemautil.ValidatedRef(s, f)
+		if !ok || !schemautil.Validates(s, t.Properties) {
+			continue
+		}
+
+		if f.Type.Type == schema.Array {
+			out(w, "        %s.forEach { it.validate() }\n", field)
+		} else if nullable {
+			out(w, "        %s?.validate()\n", field)
+		} else {
+			out(w, "        %s.validate()\n", field)
+		}
+	}
+	out(w, "    }\n")
+}
+
+// writeDeprecated writes the deprecation annotation of d to w, if any.
+func writeDeprecated(w io.Writer, d *schema.Deprecation) {
+	if d == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "@Deprecated(\"%s\")\n", escape(d.Notice()))
+}
+
+// enumValue returns the constant of the default value v of the enum values,
+// or the unknown constant.
+func enumValue(values []string, v interface{}) string {
+	if v == nil {
+		return unknownConstant(values)
+	}
+	return strcase.ToScreamingSnake(v.(string))
+}
+
+// unknownConstant returns the constant of enum values unknown to the client,
+// UNKNOWN unless one of the values is already named so.
+func unknownConstant(values []string) string {
+	var constants []string
+	for _, v := range values {
+		constants = append(constants, strcase.ToScreamingSnake(v))
+	}
+
+	name := "UNKNOWN"
+	for i := 2; slices.Contains(constants, name); i++ {
+		name = fmt.Sprintf("UNKNOWN_%d", i)
+	}
+	return name
+}
+
+// literal returns the Kotlin literal of the scalar default value of field f.
+func literal(f schema.Field) string {
+	switch v := f.Default.(type) {
+	case string:
+		return "\"" + escape(v) + "\""
+	case float64:
+		n := strconv.FormatFloat(v, 'f', -1, 64)
+		if f.Type.Type == schema.Float && !strings.Contains(n, ".") {
+			n += ".0"
+		}
+		return n
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// escape returns s escaped for use in a string literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`).Replace(s)
+}
+
 // writeFields to writer.
 func writeFieldsDoc(w io.Writer, s *schema.Schema, fields []schema.Field) {
 	for _, f := range fields {
@@ -66,10 +248,48 @@ func writeFieldsDoc(w io.Writer, s *schema.Schema, fields []schema.Field) {
 	}
 }
 
+// writeEnum writes the enum class t to w. Values unknown to this version of
+// the client are decoded as UNKNOWN, or the name unknownConstant chooses when
+// a value is named so, see coerceInputValues in the client.
+func writeEnum(w io.Writer, t schema.Type) {
+	out := fmt.Fprintf
+	out(w, "/**\n * %s %s\n */\n", strcase.ToCamel(t.Name), t.Description)
+	writeDeprecated(w, t.Deprecated)
+	out(w, "@Serializable\n")
+	out(w, "enum class %s {\n", strcase.ToCamel(t.Name))
+	for _, v := range t.Enum {
+		out(w, "    @SerialName(\"%s\") %s,\n", v, strcase.ToScreamingSnake(v))
+	}
+	out(w, "    %s\n", unknownConstant(t.Enum))
+	out(w, "}\n\n")
+}
+
+// writeUnion writes the union type t to w as a sealed interface
+// implemented by its variants.
+func writeUnion(w io.Writer, t schema.Type) {
+	out := fmt.Fprintf
+	out(w, "/**\n * %s %s\n */\n", strcase.ToCamel(t.Name), t.Description)
+	writeDeprecated(w, t.Deprecated)
+	out(w, "@OptIn(ExperimentalSerializationApi::class)\n")
+	out(w, "@Serializable\n")
+	out(w, "@JsonClassDiscriminator(\"%s\")\n", t.Discriminator)
+	out(w, "sealed interface %s\n\n", strcase.ToCamel(t.Name))
+}
+
+// hasUnions returns true if s declares any union types.
+func hasUnions(s *schema.Schema) bool {
+	for _, t := range s.Types {
+		if t.IsUnion() {
+			return true
+		}
+	}
+	return false
+}
+
 // writeFields to writer.
-func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
+func writeFields(w io.Writer, s *schema.Schema, owner string, fields []schema.Field) {
 	for i, f := range fields {
-		writeField(w, s, f)
+		writeField(w, s, owner, f)
 		if i < len(fields)-1 {
 			fmt.Fprintf(w, ",\n")
 		}
@@ -77,16 +297,45 @@ func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
 }
 
 // writeField to writer.
-func writeField(w io.Writer, s *schema.Schema, f schema.Field) {
+func writeField(w io.Writer, s *schema.Schema, owner string, f schema.Field) {
 	t := "var"
 	if f.ReadOnly {
 		t = "val"
 	}
-	fmt.Fprintf(w, "    @SerialName(\"%s\") %s %s: %s = %s", f.Name, t, strcase.ToLowerCamel(f.Name), kotlinType(s, f), defaultValue(s, f))
+
+	kt := kotlinType(s, f)
+	_, mapped := schemautil.Mapping(s, schemautil.Kotlin, f)
+	if !mapped && schemautil.IsInlineEnum(f) {
+		kt = strcase.ToCamel(schemautil.EnumName(owner, f))
+	}
+
+	// unions have no default value, and optional validated fields are null when absent
+	def := defaultValue(s, owner, f)
+	if ref := f.Type.Ref.Value; !mapped && ref != "" && schemautil.ResolveRef(s, f.Type.Ref).IsUnion() {
+		kt += "?"
+	} else if !mapped && schemautil.Nullable(s, f) {
+		kt, def = kt+"?", "null"
+	}
+
+	if d := f.Deprecated; d != nil {
+		fmt.Fprintf(w, "    @Deprecated(\"%s\")\n", escape(d.Notice()))
+	}
+
+	fmt.Fprintf(w, "    @SerialName(\"%s\") %s %s: %s = %s", f.Name, t, strcase.ToLowerCamel(f.Name), kt, def)
+}
+
+// Type returns the Kotlin type of field f, as used by the generated types.
+func Type(s *schema.Schema, f schema.Field) string {
+	return kotlinType(s, f)
 }
 
 // kotlinType returns a Kotlin equivalent type for field f.
 func kotlinType(s *schema.Schema, f schema.Field) string {
+	// mapping
+	if m, ok := schemautil.Mapping(s, schemautil.Kotlin, f); ok {
+		return m.Type
+	}
+
 	// ref
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
@@ -106,24 +355,44 @@ func kotlinType(s *schema.Schema, f schema.Field) string {
 	case schema.Timestamp:
 		return "String"
 	case schema.Object:
-		return kotlinType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		})
+		if !f.HasValues() {
+			panic("unhandled type: object without values")
+		}
+		return "Map<String, " + kotlinType(s, f.Values.Field()) + ">"
 	case schema.Array:
-		return "Array<" + kotlinType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		}) + ">"
+		return "Array<" + kotlinType(s, f.Items.Field()) + ">"
 	default:
 		panic("unhandled type")
 	}
 }
 
-func defaultValue(s *schema.Schema, f schema.Field) string {
+func defaultValue(s *schema.Schema, owner string, f schema.Field) string {
+	if m, ok := schemautil.Mapping(s, schemautil.Kotlin, f); ok {
+		if m.Zero != "" {
+			return m.Zero
+		}
+		return m.Type + "()"
+	}
+
+	if schemautil.IsInlineEnum(f) {
+		return strcase.ToCamel(schemautil.EnumName(owner, f)) + "." + enumValue(f.Enum, f.Default)
+	}
+
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
+		if t.IsEnum() {
+			return strcase.ToCamel(t.Name) + "." + enumValue(t.Enum, f.Default)
+		}
+		if t.IsUnion() {
+			return "null"
+		}
 		return strcase.ToCamel(t.Name) + "()"
 	}
 
+	if f.Default != nil {
+		return literal(f)
+	}
+
 	// type
 	switch f.Type.Type {
 	case schema.String:
@@ -137,9 +406,7 @@ func defaultValue(s *schema.Schema, f schema.Field) string {
 	case schema.Timestamp:
 		return "\"\""
 	case schema.Object:
-		return kotlinType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		}) + "()"
+		return "mapOf()"
 	case schema.Array:
 		return "arrayOf()"
 	default:
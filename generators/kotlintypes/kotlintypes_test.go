@@ -21,3 +21,47 @@ func TestGenerate_noValidate(t *testing.T) {
 
 	fixture.Assert(t, "todo_types_no_validate.kt", act.Bytes())
 }
+
+func TestGenerate_validate(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = kotlintypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_types_validate.kt", act.Bytes())
+}
+
+func TestGenerate_mappings(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/mapping.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = kotlintypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "mapping_types.kt", act.Bytes())
+}
+
+func TestGenerate_optional(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/optional.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = kotlintypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "optional_types.kt", act.Bytes())
+}
+
+func TestGenerate_enumUnknown(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/enum_unknown.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = kotlintypes.Generate(&act, schema, true)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "enum_unknown_types.kt", act.Bytes())
+}
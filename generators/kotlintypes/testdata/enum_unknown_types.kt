@@ -0,0 +1,34 @@
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+
+/**
+ * Validatable is implemented by params with client-side validation.
+ */
+interface Validatable {
+    fun validate()
+}
+
+/**
+ * ValidationError is an error of params failing validation.
+ */
+class ValidationError(message: String) : Exception(message)
+
+/**
+ * State is the state of a job.
+ */
+@Serializable
+enum class State {
+    @SerialName("known") KNOWN,
+    @SerialName("unknown") UNKNOWN,
+    UNKNOWN_2
+}
+
+/**
+ * getJob output params.
+ * @property state is the state of the job.
+ */
+@Serializable
+data class GetJobOutput(
+    @SerialName("state") var state: State = State.UNKNOWN_2
+)
+
@@ -0,0 +1,56 @@
+import java.math.BigDecimal
+import java.util.UUID
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+
+/**
+ * Validatable is implemented by params with client-side validation.
+ */
+interface Validatable {
+    fun validate()
+}
+
+/**
+ * ValidationError is an error of params failing validation.
+ */
+class ValidationError(message: String) : Exception(message)
+
+/**
+ * Product is a product.
+ * @property colors is the colors available.
+ * @property discounts is the discounted prices by code.
+ * @property name is the name. Must be at least 1 character long.
+ * @property price is the price. Must be at least 0.
+ */
+@Serializable
+data class Product(
+    @SerialName("colors") var colors: Array<String> = arrayOf(),
+    @SerialName("discounts") var discounts: Map<String, BigDecimal> = mapOf(),
+    @SerialName("name") var name: String? = null,
+    @SerialName("price") var price: BigDecimal = BigDecimal.ZERO
+) : Validatable {
+    override fun validate() {
+        if (name != null && name!!.codePointCount(0, name!!.length) < 1) {
+            throw ValidationError("name must be at least 1 character long")
+        }
+    }
+}
+
+/**
+ * getProduct input params.
+ * @property id is the product id. This field is required.
+ */
+@Serializable
+data class GetProductInput(
+    @SerialName("id") var id: UUID = UUID(0, 0)
+)
+
+/**
+ * getProduct output params.
+ * @property product is the product.
+ */
+@Serializable
+data class GetProductOutput(
+    @SerialName("product") var product: Product? = null
+)
+
@@ -0,0 +1,48 @@
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+
+/**
+ * Validatable is implemented by params with client-side validation.
+ */
+interface Validatable {
+    fun validate()
+}
+
+/**
+ * ValidationError is an error of params failing validation.
+ */
+class ValidationError(message: String) : Exception(message)
+
+/**
+ * Address is a postal address.
+ * @property street is the street. This field is required. Must be at least 1 character long.
+ */
+@Serializable
+data class Address(
+    @SerialName("street") var street: String = ""
+) : Validatable {
+    override fun validate() {
+        if (street.codePointCount(0, street.length) < 1) {
+            throw ValidationError("street must be at least 1 character long")
+        }
+    }
+}
+
+/**
+ * shipOrder input params.
+ * @property address is the delivery address, defaulting to the account address.
+ * @property quantity is the number of parcels. Must be at least 1.
+ */
+@Serializable
+data class ShipOrderInput(
+    @SerialName("address") var address: Address? = null,
+    @SerialName("quantity") var quantity: Int? = null
+) : Validatable {
+    override fun validate() {
+        address?.validate()
+        if (quantity != null && quantity!! < 1) {
+            throw ValidationError("quantity must be at least 1")
+        }
+    }
+}
+
@@ -0,0 +1,238 @@
+import kotlinx.serialization.ExperimentalSerializationApi
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.json.JsonClassDiscriminator
+
+/**
+ * Validatable is implemented by params with client-side validation.
+ */
+interface Validatable {
+    fun validate()
+}
+
+/**
+ * ValidationError is an error of params failing validation.
+ */
+class ValidationError(message: String) : Exception(message)
+
+/**
+ * ItemStatus is the status of the to-do item.
+ */
+@Serializable
+enum class ItemStatus {
+    @SerialName("pending") PENDING,
+    @SerialName("completed") COMPLETED,
+    UNKNOWN
+}
+
+/**
+ * Priority is the priority of a to-do item.
+ */
+@Serializable
+enum class Priority {
+    @SerialName("low") LOW,
+    @SerialName("normal") NORMAL,
+    @SerialName("high") HIGH,
+    UNKNOWN
+}
+
+/**
+ * Item is a to-do item.
+ * @property createdAt is the time the to-do item was created. This field is read-only.
+ * @property done is whether the to-do item is done.
+ * @property id is the id of the item. This field is read-only.
+ * @property labels is the labels of the to-do item, keyed by name.
+ * @property priority is the priority of the to-do item. Defaults to "normal".
+ * @property reminder is the reminder of the to-do item.
+ * @property status is the status of the to-do item. Must be one of: "pending", "completed".
+ * @property text is the to-do item text. This field is required. Must be at most 200 characters long.
+ * @property url is the link of the to-do item. Must be a valid URI.
+ */
+@Serializable
+data class Item(
+    @SerialName("created_at") val createdAt: String = "",
+    @Deprecated("Use status instead.")
+    @SerialName("done") var done: Boolean = false,
+    @SerialName("id") val id: Int = 0,
+    @SerialName("labels") var labels: Map<String, String> = mapOf(),
+    @SerialName("priority") var priority: Priority = Priority.NORMAL,
+    @SerialName("reminder") var reminder: Reminder? = null,
+    @SerialName("status") var status: ItemStatus = ItemStatus.UNKNOWN,
+    @SerialName("text") var text: String = "",
+    @SerialName("url") var url: String? = null
+) : Validatable {
+    override fun validate() {
+        if (text.codePointCount(0, text.length) > 200) {
+            throw ValidationError("text must be at most 200 characters long")
+        }
+        if (url != null && !Regex("""^[a-zA-Z][a-zA-Z0-9+.-]*:\S+${'$'}""").matches(url!!)) {
+            throw ValidationError("url must be a valid URI")
+        }
+    }
+}
+
+/**
+ * ItemInput is a to-do item. Read-only fields are omitted.
+ * @property done is whether the to-do item is done.
+ * @property labels is the labels of the to-do item, keyed by name.
+ * @property priority is the priority of the to-do item. Defaults to "normal".
+ * @property reminder is the reminder of the to-do item.
+ * @property status is the status of the to-do item. Must be one of: "pending", "completed".
+ * @property text is the to-do item text. This field is required. Must be at most 200 characters long.
+ * @property url is the link of the to-do item. Must be a valid URI.
+ */
+@Serializable
+data class ItemInput(
+    @Deprecated("Use status instead.")
+    @SerialName("done") var done: Boolean = false,
+    @SerialName("labels") var labels: Map<String, String> = mapOf(),
+    @SerialName("priority") var priority: Priority = Priority.NORMAL,
+    @SerialName("reminder") var reminder: Reminder? = null,
+    @SerialName("status") var status: ItemStatus = ItemStatus.UNKNOWN,
+    @SerialName("text") var text: String = "",
+    @SerialName("url") var url: String? = null
+) : Validatable {
+    override fun validate() {
+        if (text.codePointCount(0, text.length) > 200) {
+            throw ValidationError("text must be at most 200 characters long")
+        }
+        if (url != null && !Regex("""^[a-zA-Z][a-zA-Z0-9+.-]*:\S+${'$'}""").matches(url!!)) {
+            throw ValidationError("url must be a valid URI")
+        }
+    }
+}
+
+/**
+ * LocationReminder is a reminder when arriving at a location.
+ * @property latitude is the latitude of the location. This field is required.
+ * @property longitude is the longitude of the location. This field is required.
+ */
+@Serializable
+@SerialName("location_reminder")
+data class LocationReminder(
+    @SerialName("latitude") var latitude: Double = 0.0,
+    @SerialName("longitude") var longitude: Double = 0.0
+) : Reminder
+
+/**
+ * Reminder is a reminder for a to-do item.
+ */
+@OptIn(ExperimentalSerializationApi::class)
+@Serializable
+@JsonClassDiscriminator("type")
+sealed interface Reminder
+
+/**
+ * Stats is the statistics of a to-do list.
+ * @property completed is the number of completed items.
+ * @property total is the number of items.
+ */
+@Serializable
+data class Stats(
+    @SerialName("completed") var completed: Int = 0,
+    @SerialName("total") var total: Int = 0
+)
+
+/**
+ * TimeReminder is a reminder at a point in time.
+ * @property at is the time to remind at. This field is required.
+ */
+@Serializable
+@SerialName("time_reminder")
+data class TimeReminder(
+    @SerialName("at") var at: String = ""
+) : Reminder
+
+/**
+ * addItem input params.
+ * @property item is the item to add. This field is required. Must be at least 1 character long. Must be at most 200 characters long.
+ * @property priority is the priority of the item. Defaults to "normal".
+ */
+@Serializable
+data class AddItemInput(
+    @SerialName("item") var item: String = "",
+    @SerialName("priority") var priority: Priority = Priority.NORMAL
+) : Validatable {
+    override fun validate() {
+        if (item.codePointCount(0, item.length) < 1) {
+            throw ValidationError("item must be at least 1 character long")
+        }
+        if (item.codePointCount(0, item.length) > 200) {
+            throw ValidationError("item must be at most 200 characters long")
+        }
+    }
+}
+
+/**
+ * getItems output params.
+ * @property items is the list of to-do items.
+ * @property lists is the to-do items grouped by list name.
+ */
+@Serializable
+data class GetItemsOutput(
+    @SerialName("items") var items: Array<Item> = arrayOf(),
+    @SerialName("lists") var lists: Map<String, Array<Item>> = mapOf()
+)
+
+/**
+ * getStats output params.
+ * @property stats is the statistics.
+ */
+@Serializable
+data class GetStatsOutput(
+    @SerialName("stats") var stats: Stats = Stats()
+)
+
+/**
+ * removeItem input params.
+ * @property id is the id of the item to remove. Must be at least 1.
+ */
+@Deprecated("Set the item status to completed instead. Removal is scheduled for 2027-06-01.")
+@Serializable
+data class RemoveItemInput(
+    @SerialName("id") var id: Int? = null
+) : Validatable {
+    override fun validate() {
+        if (id != null && id!! < 1) {
+            throw ValidationError("id must be at least 1")
+        }
+    }
+}
+
+/**
+ * removeItem output params.
+ * @property item is the item removed.
+ */
+@Deprecated("Set the item status to completed instead. Removal is scheduled for 2027-06-01.")
+@Serializable
+data class RemoveItemOutput(
+    @SerialName("item") var item: Item? = null
+)
+
+/**
+ * updateItem input params.
+ * @property id is the id of the item to update. This field is required. Must be at least 1.
+ * @property item is the updated item. This field is required.
+ */
+@Serializable
+data class UpdateItemInput(
+    @SerialName("id") var id: Int = 0,
+    @SerialName("item") var item: ItemInput = ItemInput()
+) : Validatable {
+    override fun validate() {
+        if (id < 1) {
+            throw ValidationError("id must be at least 1")
+        }
+        item.validate()
+    }
+}
+
+/**
+ * updateItem output params.
+ * @property item is the item updated.
+ */
+@Serializable
+data class UpdateItemOutput(
+    @SerialName("item") var item: Item? = null
+)
+
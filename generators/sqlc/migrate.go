@@ -44,7 +44,7 @@ func generateLoad(w io.Writer, ts []schema.Type) {
 	out := fmt.Fprintf
 	out(w, "func load(ctx context.Context, db *gorm.DB) {\n")
 	for _, t := range ts {
-		if t.Name == "index" || t.Name == "index2" {
+		if t.Name == "index" || t.Name == "index2" || t.IsEnum() || t.IsUnion() {
 			continue
 		}
 		out(w, "%sf, err := os.Open(\"%s.json\")\n", t.Name, t.Name)
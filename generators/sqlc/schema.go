@@ -50,6 +50,12 @@ func sqlType(s *schema.Schema, f schema.Field) string {
 	// ref
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
+		if t.IsEnum() {
+			return "TEXT"
+		}
+		if t.IsUnion() {
+			return "JSONB"
+		}
 		return format.GoName(t.Name)
 	}
 
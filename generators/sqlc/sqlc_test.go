@@ -16,7 +16,7 @@ func TestGenerate_noValidate(t *testing.T) {
 	assert.NoError(t, err, "loading schema")
 
 	var act bytes.Buffer
-	err = gotypes.Generate(&act, schema)
+	err = gotypes.Generate(&act, schema, false)
 	assert.NoError(t, err, "generating")
 
 	fixture.Assert(t, "todo_types_no_validate.go", act.Bytes())
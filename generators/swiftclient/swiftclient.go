@@ -3,9 +3,12 @@ package swiftclient
 import (
 	"fmt"
 	"io"
+	"strings"
 
-	"github.com/newlix/rpc/schema"
 	"github.com/iancoleman/strcase"
+	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
 )
 
 var start = `import Foundation
@@ -30,7 +33,7 @@ struct %s {
 `
 var end = `
     // call implementation.
-    private func call<Input, Output>(method: String, input: Input, complete: @escaping (_ output: Output?, _ error: Error?) -> Void) where Input: Codable, Output: Codable {
+    fileprivate func call<Input, Output>(method: String, input: Input, complete: @escaping (_ output: Output?, _ error: Error?) -> Void) where Input: Codable, Output: Codable {
 
         var url = self.url
         url.appendPathComponent(method, isDirectory: false)
@@ -43,11 +46,15 @@ var end = `
         }
 
         do {
+            if let input = input as? Validatable {
+                try input.validate()
+            }
             if !(input is Nothing) {
                 r.httpBody = try self.encoder.encode(input)
             }
         } catch {
             complete(nil, error)
+            return
         }
 
         self.session.dataTask(with: r) { (data, response, resError) in
@@ -114,63 +121,98 @@ func Generate(w io.Writer, s *schema.Schema, client string) error {
 
 	out(w, start, client, client)
 
-	for _, m := range s.Methods {
+	// ungrouped methods
+	writeMethods(w, schemautil.GroupMethods(s, ""), "call")
+
+	// groups
+	for _, g := range schemautil.Groups(s) {
+		out(w, "    // %s returns the client of the %s group.\n", strcase.ToLowerCamel(g.Name), g.Name)
+		out(w, "    // %s\n", g.Summary)
+		out(w, "    var %s: %s%s { %s%s(client: self) }\n\n", strcase.ToLowerCamel(g.Name), format.GoName(g.Name), client, format.GoName(g.Name), client)
+	}
+
+	out(w, end)
+
+	for _, g := range schemautil.Groups(s) {
+		out(w, "\n")
+		out(w, "// %s%s is the client of the %s group.\n", format.GoName(g.Name), client, g.Name)
+		out(w, "//\n")
+		out(w, "// %s\n", g.Description)
+		out(w, "struct %s%s {\n", format.GoName(g.Name), client)
+		out(w, "    // client is the API client making the calls.\n")
+		out(w, "    let client: %s\n", client)
+		out(w, "\n")
+		writeMethods(w, schemautil.GroupMethods(s, g.Name), "client.call")
+		out(w, "}\n")
+	}
+
+	return nil
+}
+
+// writeMethods writes methods to w, invoking the call function named call.
+func writeMethods(w io.Writer, methods []schema.Method, call string) {
+	out := fmt.Fprintf
+	for _, m := range methods {
 		name := strcase.ToLowerCamel(m.Name)
 		out(w, "    // %s %s\n", name, m.Description)
+		if d := m.Deprecated; d != nil {
+			out(w, "    @available(*, deprecated, message: \"%s\")\n", escape(d.Notice()))
+		}
 
 		if len(m.Inputs) > 0 && len(m.Outputs) == 0 {
-			writeInputOnlyMethod(w, m)
+			writeInputOnlyMethod(w, m, call)
 			continue
 		}
 
 		if len(m.Inputs) == 0 && len(m.Outputs) > 0 {
-			writeOutputOnlyMethod(w, m)
+			writeOutputOnlyMethod(w, m, call)
 			continue
 		}
 
 		if len(m.Inputs) > 0 && len(m.Outputs) > 0 {
-			writeMethod(w, m)
+			writeMethod(w, m, call)
 			continue
 		}
 	}
+}
 
-	out(w, end)
-
-	return nil
+// escape returns s escaped for use in a string literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
 }
 
-func writeInputOnlyMethod(w io.Writer, m schema.Method) {
+func writeInputOnlyMethod(w io.Writer, m schema.Method, call string) {
 	camel := strcase.ToCamel(m.Name)
 	lcamel := strcase.ToLowerCamel(m.Name)
 	template := `    func %s(input: %sInput, complete: @escaping (_ error: Error?) -> ()) {
-        call(method: "%s", input: input, complete: { (_: Nothing?, err: Error?) in complete(err) })
+        %s(method: "%s", input: input, complete: { (_: Nothing?, err: Error?) in complete(err) })
     }
 
 `
-	fmt.Fprintf(w, template, lcamel, camel, m.Name)
+	fmt.Fprintf(w, template, lcamel, camel, call, m.Name)
 
 }
 
-func writeOutputOnlyMethod(w io.Writer, m schema.Method) {
+func writeOutputOnlyMethod(w io.Writer, m schema.Method, call string) {
 	camel := strcase.ToCamel(m.Name)
 	lcamel := strcase.ToLowerCamel(m.Name)
 	template := `    func %s(complete: @escaping (_ output: %sOutput?, _ err: Error?) -> ()) {
-        call(method: "%s", input: Nothing(), complete: complete)
+        %s(method: "%s", input: Nothing(), complete: complete)
     }
 
 `
-	fmt.Fprintf(w, template, lcamel, camel, m.Name)
+	fmt.Fprintf(w, template, lcamel, camel, call, m.Name)
 
 }
 
-func writeMethod(w io.Writer, m schema.Method) {
+func writeMethod(w io.Writer, m schema.Method, call string) {
 	camel := strcase.ToCamel(m.Name)
 	lcamel := strcase.ToLowerCamel(m.Name)
 	template := `    func %s(input: %sInput, complete: @escaping (_ output: %sOutput?, _ error: Error?) -> Void) {
-        call(method: "%s", input: input, complete: complete)
+        %s(method: "%s", input: input, complete: complete)
     }
 
 `
-	fmt.Fprintf(w, template, lcamel, camel, camel, m.Name)
+	fmt.Fprintf(w, template, lcamel, camel, camel, call, m.Name)
 
 }
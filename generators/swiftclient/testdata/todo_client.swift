@@ -17,24 +17,18 @@ struct Client {
     // session is the client used for making requests, defaulting to URLSession.shared.
     let session: URLSession = URLSession.shared
 
-    // addItem adds an item to the list.
-    func addItem(input: AddItemInput, complete: @escaping (_ error: Error?) -> ()) {
-        call(method: "add_item", input: input, complete: { (_: Nothing?, err: Error?) in complete(err) })
+    // getStats returns statistics of the to-do list, for administrators.
+    func getStats(complete: @escaping (_ output: GetStatsOutput?, _ err: Error?) -> ()) {
+        call(method: "get_stats", input: Nothing(), complete: complete)
     }
 
-    // getItems returns all items in the list.
-    func getItems(complete: @escaping (_ output: GetItemsOutput?, _ err: Error?) -> ()) {
-        call(method: "get_items", input: Nothing(), complete: complete)
-    }
-
-    // removeItem removes an item from the to-do list.
-    func removeItem(input: RemoveItemInput, complete: @escaping (_ output: RemoveItemOutput?, _ error: Error?) -> Void) {
-        call(method: "remove_item", input: input, complete: complete)
-    }
+    // items returns the client of the items group.
+    // Manage the items of the to-do list.
+    var items: ItemsClient { ItemsClient(client: self) }
 
 
     // call implementation.
-    private func call<Input, Output>(method: String, input: Input, complete: @escaping (_ output: Output?, _ error: Error?) -> Void) where Input: Codable, Output: Codable {
+    fileprivate func call<Input, Output>(method: String, input: Input, complete: @escaping (_ output: Output?, _ error: Error?) -> Void) where Input: Codable, Output: Codable {
 
         var url = self.url
         url.appendPathComponent(method, isDirectory: false)
@@ -47,11 +41,15 @@ struct Client {
         }
 
         do {
+            if let input = input as? Validatable {
+                try input.validate()
+            }
             if !(input is Nothing) {
                 r.httpBody = try self.encoder.encode(input)
             }
         } catch {
             complete(nil, error)
+            return
         }
 
         self.session.dataTask(with: r) { (data, response, resError) in
@@ -110,3 +108,33 @@ extension String: Error {
 struct Nothing: Codable {
 
 }
+
+// ItemsClient is the client of the items group.
+//
+// The items group provides methods for adding, listing and removing to-do items.
+struct ItemsClient {
+    // client is the API client making the calls.
+    let client: Client
+
+    // addItem adds an item to the list.
+    func addItem(input: AddItemInput, complete: @escaping (_ error: Error?) -> ()) {
+        client.call(method: "add_item", input: input, complete: { (_: Nothing?, err: Error?) in complete(err) })
+    }
+
+    // getItems returns all items in the list.
+    func getItems(complete: @escaping (_ output: GetItemsOutput?, _ err: Error?) -> ()) {
+        client.call(method: "get_items", input: Nothing(), complete: complete)
+    }
+
+    // removeItem removes an item from the to-do list.
+    @available(*, deprecated, message: "Set the item status to completed instead. Removal is scheduled for 2027-06-01.")
+    func removeItem(input: RemoveItemInput, complete: @escaping (_ output: RemoveItemOutput?, _ error: Error?) -> Void) {
+        client.call(method: "remove_item", input: input, complete: complete)
+    }
+
+    // updateItem updates an item in the to-do list.
+    func updateItem(input: UpdateItemInput, complete: @escaping (_ output: UpdateItemOutput?, _ error: Error?) -> Void) {
+        client.call(method: "update_item", input: input, complete: complete)
+    }
+
+}
@@ -3,6 +3,9 @@ package swifttypes
 import (
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/iancoleman/strcase"
 	"github.com/newlix/rpc/internal/format"
@@ -12,20 +15,54 @@ import (
 
 // Generate writes the Go type implementations to w, with optional validation methods.
 func Generate(w io.Writer, s *schema.Schema, validate bool) error {
+	s = schemautil.Inputs(s)
 	out := fmt.Fprintf
 	out(w, "import Foundation\n")
+	for _, module := range schemautil.Imports(s, schemautil.Swift) {
+		out(w, "import %s\n", module)
+	}
+	out(w, "\n")
+	out(w, "// Validatable is implemented by params with client-side validation.\n")
+	out(w, "protocol Validatable {\n")
+	out(w, "    func validate() throws\n")
+	out(w, "}\n")
+	out(w, "\n")
+	out(w, "// ValidationError is an error of params failing validation.\n")
+	out(w, "struct ValidationError: Error {\n")
+	out(w, "    let message: String\n")
+	out(w, "}\n")
 	out(w, "\n")
 
+	// enums
+	for _, t := range schemautil.Enums(s) {
+		if _, ok := schemautil.TypeMapping(schemautil.Swift, t); ok {
+			continue
+		}
+		writeEnum(w, t)
+	}
+
 	// types
 	for _, t := range s.TypesSlice() {
+		if _, ok := schemautil.TypeMapping(schemautil.Swift, t); ok || t.IsEnum() {
+			continue
+		}
+		if t.IsUnion() {
+			writeUnion(w, s, t)
+			continue
+		}
 		out(w, "// %s %s\n", format.GoName(t.Name), t.Description)
+		writeDeprecated(w, "", t.Deprecated)
 		out(w, "struct %s: Codable {\n", format.GoName(t.Name))
-		writeFields(w, s, t.Properties)
+		writeFields(w, s, schemautil.Owner(t), t.Properties)
 		out(w, "\n")
 		writeCodingKeys(w, s, t.Properties)
 		out(w, "}\n")
 		out(w, "\n")
-		writeDecoderInit(w, format.GoName(t.Name), s, t.Properties)
+		writeDecoderInit(w, format.GoName(t.Name), s, schemautil.Owner(t), t.Properties)
+		if validate && schemautil.Validates(s, t.Properties) {
+			out(w, "\n")
+			writeValidate(w, s, format.GoName(t.Name), t.Properties)
+		}
 	}
 
 	// methods
@@ -35,13 +72,18 @@ func Generate(w io.Writer, s *schema.Schema, validate bool) error {
 		// inputs
 		if len(m.Inputs) > 0 {
 			out(w, "// %sInput params.\n", name)
+			writeDeprecated(w, "", m.Deprecated)
 			out(w, "struct %sInput: Codable {\n", name)
-			writeFields(w, s, m.Inputs)
+			writeFields(w, s, m.Name+"_input", m.Inputs)
 			out(w, "\n")
 			writeCodingKeys(w, s, m.Inputs)
 			out(w, "}\n")
 			out(w, "\n")
-			writeDecoderInit(w, name+"Input", s, m.Inputs)
+			writeDecoderInit(w, name+"Input", s, m.Name+"_input", m.Inputs)
+			if validate && schemautil.Validates(s, m.Inputs) {
+				out(w, "\n")
+				writeValidate(w, s, name+"Input", m.Inputs)
+			}
 		}
 
 		// both
@@ -52,13 +94,14 @@ func Generate(w io.Writer, s *schema.Schema, validate bool) error {
 		// outputs
 		if len(m.Outputs) > 0 {
 			out(w, "// %sOutput params.\n", name)
+			writeDeprecated(w, "", m.Deprecated)
 			out(w, "struct %sOutput: Codable {\n", name)
-			writeFields(w, s, m.Outputs)
+			writeFields(w, s, m.Name+"_output", m.Outputs)
 			out(w, "\n")
 			writeCodingKeys(w, s, m.Outputs)
 			out(w, "}\n")
 			out(w, "\n")
-			writeDecoderInit(w, name+"Output", s, m.Outputs)
+			writeDecoderInit(w, name+"Output", s, m.Name+"_output", m.Outputs)
 		}
 
 		out(w, "\n")
@@ -68,10 +111,86 @@ func Generate(w io.Writer, s *schema.Schema, validate bool) error {
 	return nil
 }
 
+// writeEnum writes the enum type t to w. Values unknown to this
+// version of the client are decoded as the unknown case.
+func writeEnum(w io.Writer, t schema.Type) {
+	out := fmt.Fprintf
+	name := format.GoName(t.Name)
+	unknown := unknownCase(enumCases(t.Enum))
+	out(w, "// %s %s\n", name, t.Description)
+	writeDeprecated(w, "", t.Deprecated)
+	out(w, "enum %s: String, Codable {\n", name)
+	for _, v := range t.Enum {
+		out(w, "    case %s = \"%s\"\n", strcase.ToLowerCamel(v), v)
+	}
+	out(w, "\n")
+	out(w, "    // %s is a value not known to this version of the client.\n", unknown)
+	out(w, "    case %s = \"\"\n", unknown)
+	out(w, "\n")
+	out(w, "    init(from decoder: Decoder) throws {\n")
+	out(w, "        let value = try decoder.singleValueContainer().decode(String.self)\n")
+	out(w, "        self = %s(rawValue: value) ?? .%s\n", name, unknown)
+	out(w, "    }\n")
+	out(w, "}\n")
+	out(w, "\n")
+}
+
+// writeUnion writes the union type t to w as an enum with associated values.
+// Variants unknown to this version of the client are decoded as the unknown case.
+func writeUnion(w io.Writer, s *schema.Schema, t schema.Type) {
+	out := fmt.Fprintf
+	name := format.GoName(t.Name)
+	key := strcase.ToLowerCamel(format.GoName(t.Discriminator))
+	variants := schemautil.Variants(s, t)
+	unknown := unknownCase(variantCases(variants))
+
+	out(w, "// %s %s\n", name, t.Description)
+	writeDeprecated(w, "", t.Deprecated)
+	out(w, "enum %s: Codable {\n", name)
+	for _, v := range variants {
+		out(w, "    case %s(%s)\n", strcase.ToLowerCamel(v.Name), format.GoName(v.Name))
+	}
+	out(w, "\n")
+	out(w, "    // %s is a variant not known to this version of the client.\n", unknown)
+	out(w, "    case %s(String)\n", unknown)
+	out(w, "\n")
+	out(w, "    enum DiscriminatorKeys: String, CodingKey {\n")
+	out(w, "        case %s = \"%s\"\n", key, t.Discriminator)
+	out(w, "    }\n")
+	out(w, "\n")
+	out(w, "    init(from decoder: Decoder) throws {\n")
+	out(w, "        let container = try decoder.container(keyedBy: DiscriminatorKeys.self)\n")
+	out(w, "        let variant = try container.decode(String.self, forKey: .%s)\n", key)
+	out(w, "        switch variant {\n")
+	for _, v := range variants {
+		out(w, "        case \"%s\":\n", v.Name)
+		out(w, "            self = .%s(try %s(from: decoder))\n", strcase.ToLowerCamel(v.Name), format.GoName(v.Name))
+	}
+	out(w, "        default:\n")
+	out(w, "            self = .%s(variant)\n", unknown)
+	out(w, "        }\n")
+	out(w, "    }\n")
+	out(w, "\n")
+	out(w, "    func encode(to encoder: Encoder) throws {\n")
+	out(w, "        var container = encoder.container(keyedBy: DiscriminatorKeys.self)\n")
+	out(w, "        switch self {\n")
+	for _, v := range variants {
+		out(w, "        case .%s(let value):\n", strcase.ToLowerCamel(v.Name))
+		out(w, "            try container.encode(\"%s\", forKey: .%s)\n", v.Name, key)
+		out(w, "            try value.encode(to: encoder)\n")
+	}
+	out(w, "        case .%s(let variant):\n", unknown)
+	out(w, "            try container.encode(variant, forKey: .%s)\n", key)
+	out(w, "        }\n")
+	out(w, "    }\n")
+	out(w, "}\n")
+	out(w, "\n")
+}
+
 // writeFields to writer.
-func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
+func writeFields(w io.Writer, s *schema.Schema, owner string, fields []schema.Field) {
 	for i, f := range fields {
-		writeField(w, s, f)
+		writeField(w, s, owner, f)
 		if i < len(fields)-1 {
 			fmt.Fprintf(w, "\n")
 		}
@@ -79,10 +198,27 @@ func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
 }
 
 // writeField to writer.
-func writeField(w io.Writer, s *schema.Schema, f schema.Field) {
+func writeField(w io.Writer, s *schema.Schema, owner string, f schema.Field) {
 	name := strcase.ToLowerCamel(format.GoName(f.Name))
+	t, v := fieldType(s, owner, f), defaultValue(s, f)
+
+	// optional validated fields are nil when absent
+	if !schemautil.IsMapped(s, schemautil.Swift, f) && schemautil.Nullable(s, f) {
+		t, v = t+"?", "nil"
+	}
+
 	fmt.Fprintf(w, "    // %s is %s%s\n", name, f.Description, schemautil.FormatExtra(f))
-	fmt.Fprintf(w, "    var %s: %s = %s\n", name, swiftType(s, f), defaultValue(s, f))
+	writeDeprecated(w, "    ", f.Deprecated)
+	fmt.Fprintf(w, "    var %s: %s = %s\n", name, t, v)
+}
+
+// writeDeprecated writes the deprecation attribute of d to w, if any.
+func writeDeprecated(w io.Writer, indent string, d *schema.Deprecation) {
+	if d == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "%s@available(*, deprecated, message: \"%s\")\n", indent, escape(d.Notice()))
 }
 
 // writeCodingKeys to writer
@@ -100,14 +236,14 @@ func writeCodingKey(w io.Writer, s *schema.Schema, f schema.Field) {
 }
 
 // writeCodingKeys to writer
-func writeDecoderInit(w io.Writer, extensionName string, s *schema.Schema, fields []schema.Field) {
+func writeDecoderInit(w io.Writer, extensionName string, s *schema.Schema, owner string, fields []schema.Field) {
 	out := fmt.Fprintf
 	out(w, "extension %s {\n", extensionName)
 	out(w, "    init(from decoder: Decoder) throws {\n")
 	out(w, "        let container = try decoder.container(keyedBy: CodingKeys.self)\n")
 	for _, f := range fields {
 		name := strcase.ToLowerCamel(format.GoName(f.Name))
-		out(w, "        if let %s = try container.decodeIfPresent(%s.self, forKey: .%s) {\n", name, swiftType(s, f), name)
+		out(w, "        if let %s = try container.decodeIfPresent(%s.self, forKey: .%s) {\n", name, fieldType(s, owner, f), name)
 		out(w, "            self.%s = %s\n", name, name)
 		out(w, "        }\n")
 	}
@@ -115,8 +251,26 @@ func writeDecoderInit(w io.Writer, extensionName string, s *schema.Schema, field
 	out(w, "}\n")
 }
 
+// fieldType returns a Swift equivalent type for field f of owner.
+func fieldType(s *schema.Schema, owner string, f schema.Field) string {
+	if _, ok := schemautil.Mapping(s, schemautil.Swift, f); !ok && schemautil.IsInlineEnum(f) {
+		return format.GoName(schemautil.EnumName(owner, f))
+	}
+	return swiftType(s, f)
+}
+
+// Type returns the Swift type of field f, as used by the generated types.
+func Type(s *schema.Schema, f schema.Field) string {
+	return swiftType(s, f)
+}
+
 // swiftType returns a Go equivalent type for field f.
 func swiftType(s *schema.Schema, f schema.Field) string {
+	// mapping
+	if m, ok := schemautil.Mapping(s, schemautil.Swift, f); ok {
+		return m.Type
+	}
+
 	// ref
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
@@ -136,24 +290,41 @@ func swiftType(s *schema.Schema, f schema.Field) string {
 	case schema.Timestamp:
 		return "Date"
 	case schema.Object:
-		return swiftType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		})
+		if !f.HasValues() {
+			panic("unhandled type: object without values")
+		}
+		return "[String: " + swiftType(s, f.Values.Field()) + "]"
 	case schema.Array:
-		return "[" + swiftType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		}) + "]"
+		return "[" + swiftType(s, f.Items.Field()) + "]"
 	default:
 		panic("unhandled type")
 	}
 }
 
 func defaultValue(s *schema.Schema, f schema.Field) string {
+	if m, ok := schemautil.Mapping(s, schemautil.Swift, f); ok {
+		return zeroValue(m)
+	}
+
+	if schemautil.IsInlineEnum(f) {
+		return enumValue(f.Enum, f.Default)
+	}
+
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
+		if t.IsEnum() {
+			return enumValue(t.Enum, f.Default)
+		}
+		if t.IsUnion() {
+			return "." + unknownCase(variantCases(schemautil.Variants(s, t))) + "(\"\")"
+		}
 		return strcase.ToCamel(t.Name) + "()"
 	}
 
+	if f.Default != nil {
+		return literal(f.Default)
+	}
+
 	// type
 	switch f.Type.Type {
 	case schema.String:
@@ -167,9 +338,7 @@ func defaultValue(s *schema.Schema, f schema.Field) string {
 	case schema.Timestamp:
 		return "Date()"
 	case schema.Object:
-		return swiftType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		}) + "()"
+		return "[:]"
 	case schema.Array:
 		return "[]"
 	default:
@@ -177,4 +346,117 @@ func defaultValue(s *schema.Schema, f schema.Field) string {
 	}
 }
 
-//TODO validation
+// writeValidate writes the Validatable conformance of the struct name to w,
+// checking the constraints of its fields and the structs they reference.
+func writeValidate(w io.Writer, s *schema.Schema, name string, fields []schema.Field) {
+	out := fmt.Fprintf
+	out(w, "extension %s: Validatable {\n", name)
+	out(w, "    func validate() throws {\n")
+	for _, f := range fields {
+		if schemautil.IsMapped(s, schemautil.Swift, f) {
+			continue
+		}
+		field := strcase.ToLowerCamel(format.GoName(f.Name))
+		nullable := schemautil.Nullable(s, f)
+		for _, c := range schemautil.Checks(f) {
+			var cond string
+			switch c.Kind {
+			case schemautil.MinLength:
+				cond = fmt.Sprintf("%s.count < %d", field, c.Length)
+			case schemautil.MaxLength:
+				cond = fmt.Sprintf("%s.count > %d", field, c.Length)
+			case schemautil.Pattern, schemautil.Format:
+				cond = fmt.Sprintf("%s.range(of: #\"%s\"#, options: .regularExpression) == nil", field, c.Pattern)
+			case schemautil.Minimum:
+				cond = fmt.Sprintf("%s < %s", field, c.Number)
+			case schemautil.Maximum:
+				cond = fmt.Sprintf("%s > %s", field, c.Number)
+			}
+
+			// optional fields are only checked when present
+			if nullable {
+				cond = fmt.Sprintf("let %s = %s, %s", field, field, cond)
+			}
+
+			out(w, "        if %s {\n", cond)
+			out(w, "            throw ValidationError(message: \"%s\")\n", escape(f.Name+" "+c.Message))
+			out(w, "        }\n")
+		}
+
+		t, ok := schemautil.ValidatedRef(s, f)
+		if !ok || !schemautil.Validates(s, t.Properties) {
+			continue
+		}
+
+		if f.Type.Type == schema.Array {
+			out(w, "        try %s.forEach { try $0.validate() }\n", field)
+		} else if nullable {
+			out(w, "        try %s?.validate()\n", field)
+		} else {
+			out(w, "        try %s.validate()\n", field)
+		}
+	}
+	out(w, "    }\n")
+	out(w, "}\n")
+}
+
+// zeroValue returns the zero value of the mapped type m, defaulting to its
+// empty initializer.
+func zeroValue(m schema.Mapping) string {
+	if m.Zero != "" {
+		return m.Zero
+	}
+	return m.Type + "()"
+}
+
+// enumValue returns the case of the default value v of the enum values, or
+// the unknown case.
+func enumValue(values []string, v interface{}) string {
+	if v == nil {
+		return "." + unknownCase(enumCases(values))
+	}
+	return "." + strcase.ToLowerCamel(v.(string))
+}
+
+// enumCases returns the case names of the enum values.
+func enumCases(values []string) (v []string) {
+	for _, value := range values {
+		v = append(v, strcase.ToLowerCamel(value))
+	}
+	return
+}
+
+// variantCases returns the case names of the union variants.
+func variantCases(variants []schema.Type) (v []string) {
+	for _, t := range variants {
+		v = append(v, strcase.ToLowerCamel(t.Name))
+	}
+	return
+}
+
+// unknownCase returns the name of the case of values or variants unknown to
+// the client, "unknown" unless one of the cases is already named so.
+func unknownCase(cases []string) string {
+	name := "unknown"
+	for i := 2; slices.Contains(cases, name); i++ {
+		name = fmt.Sprintf("unknown%d", i)
+	}
+	return name
+}
+
+// literal returns the Swift literal of the scalar default value v.
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return "\"" + escape(v) + "\""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// escape returns s escaped for use in a string literal.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
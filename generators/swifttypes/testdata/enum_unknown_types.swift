@@ -0,0 +1,45 @@
+import Foundation
+
+// Validatable is implemented by params with client-side validation.
+protocol Validatable {
+    func validate() throws
+}
+
+// ValidationError is an error of params failing validation.
+struct ValidationError: Error {
+    let message: String
+}
+
+// State is the state of a job.
+enum State: String, Codable {
+    case known = "known"
+    case unknown = "unknown"
+
+    // unknown2 is a value not known to this version of the client.
+    case unknown2 = ""
+
+    init(from decoder: Decoder) throws {
+        let value = try decoder.singleValueContainer().decode(String.self)
+        self = State(rawValue: value) ?? .unknown2
+    }
+}
+
+// GetJobOutput params.
+struct GetJobOutput: Codable {
+    // state is the state of the job.
+    var state: State = .unknown2
+
+    enum CodingKeys: String, CodingKey {
+        case state = "state"
+    }
+}
+
+extension GetJobOutput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let state = try container.decodeIfPresent(State.self, forKey: .state) {
+            self.state = state
+        }
+    }
+}
+
@@ -0,0 +1,97 @@
+import Foundation
+
+// Validatable is implemented by params with client-side validation.
+protocol Validatable {
+    func validate() throws
+}
+
+// ValidationError is an error of params failing validation.
+struct ValidationError: Error {
+    let message: String
+}
+
+// Product is a product.
+struct Product: Codable {
+    // colors is the colors available.
+    var colors: [String] = []
+
+    // discounts is the discounted prices by code.
+    var discounts: [String: Decimal] = [:]
+
+    // name is the name. Must be at least 1 character long.
+    var name: String? = nil
+
+    // price is the price. Must be at least 0.
+    var price: Decimal = Decimal()
+
+    enum CodingKeys: String, CodingKey {
+        case colors = "colors"
+        case discounts = "discounts"
+        case name = "name"
+        case price = "price"
+    }
+}
+
+extension Product {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let colors = try container.decodeIfPresent([String].self, forKey: .colors) {
+            self.colors = colors
+        }
+        if let discounts = try container.decodeIfPresent([String: Decimal].self, forKey: .discounts) {
+            self.discounts = discounts
+        }
+        if let name = try container.decodeIfPresent(String.self, forKey: .name) {
+            self.name = name
+        }
+        if let price = try container.decodeIfPresent(Decimal.self, forKey: .price) {
+            self.price = price
+        }
+    }
+}
+
+extension Product: Validatable {
+    func validate() throws {
+        if let name = name, name.count < 1 {
+            throw ValidationError(message: "name must be at least 1 character long")
+        }
+    }
+}
+// GetProductInput params.
+struct GetProductInput: Codable {
+    // id is the product id. This field is required.
+    var id: UUID = UUID()
+
+    enum CodingKeys: String, CodingKey {
+        case id = "id"
+    }
+}
+
+extension GetProductInput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let id = try container.decodeIfPresent(UUID.self, forKey: .id) {
+            self.id = id
+        }
+    }
+}
+
+// GetProductOutput params.
+struct GetProductOutput: Codable {
+    // product is the product.
+    var product: Product? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case product = "product"
+    }
+}
+
+extension GetProductOutput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let product = try container.decodeIfPresent(Product.self, forKey: .product) {
+            self.product = product
+        }
+    }
+}
+
@@ -0,0 +1,73 @@
+import Foundation
+
+// Validatable is implemented by params with client-side validation.
+protocol Validatable {
+    func validate() throws
+}
+
+// ValidationError is an error of params failing validation.
+struct ValidationError: Error {
+    let message: String
+}
+
+// Address is a postal address.
+struct Address: Codable {
+    // street is the street. This field is required. Must be at least 1 character long.
+    var street: String = ""
+
+    enum CodingKeys: String, CodingKey {
+        case street = "street"
+    }
+}
+
+extension Address {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let street = try container.decodeIfPresent(String.self, forKey: .street) {
+            self.street = street
+        }
+    }
+}
+
+extension Address: Validatable {
+    func validate() throws {
+        if street.count < 1 {
+            throw ValidationError(message: "street must be at least 1 character long")
+        }
+    }
+}
+// ShipOrderInput params.
+struct ShipOrderInput: Codable {
+    // address is the delivery address, defaulting to the account address.
+    var address: Address? = nil
+
+    // quantity is the number of parcels. Must be at least 1.
+    var quantity: Int? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case address = "address"
+        case quantity = "quantity"
+    }
+}
+
+extension ShipOrderInput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let address = try container.decodeIfPresent(Address.self, forKey: .address) {
+            self.address = address
+        }
+        if let quantity = try container.decodeIfPresent(Int.self, forKey: .quantity) {
+            self.quantity = quantity
+        }
+    }
+}
+
+extension ShipOrderInput: Validatable {
+    func validate() throws {
+        try address?.validate()
+        if let quantity = quantity, quantity < 1 {
+            throw ValidationError(message: "quantity must be at least 1")
+        }
+    }
+}
+
@@ -0,0 +1,491 @@
+import Foundation
+
+// Validatable is implemented by params with client-side validation.
+protocol Validatable {
+    func validate() throws
+}
+
+// ValidationError is an error of params failing validation.
+struct ValidationError: Error {
+    let message: String
+}
+
+// ItemStatus is the status of the to-do item.
+enum ItemStatus: String, Codable {
+    case pending = "pending"
+    case completed = "completed"
+
+    // unknown is a value not known to this version of the client.
+    case unknown = ""
+
+    init(from decoder: Decoder) throws {
+        let value = try decoder.singleValueContainer().decode(String.self)
+        self = ItemStatus(rawValue: value) ?? .unknown
+    }
+}
+
+// Priority is the priority of a to-do item.
+enum Priority: String, Codable {
+    case low = "low"
+    case normal = "normal"
+    case high = "high"
+
+    // unknown is a value not known to this version of the client.
+    case unknown = ""
+
+    init(from decoder: Decoder) throws {
+        let value = try decoder.singleValueContainer().decode(String.self)
+        self = Priority(rawValue: value) ?? .unknown
+    }
+}
+
+// Item is a to-do item.
+struct Item: Codable {
+    // createdAt is the time the to-do item was created. This field is read-only.
+    var createdAt: Date = Date()
+
+    // done is whether the to-do item is done.
+    @available(*, deprecated, message: "Use status instead.")
+    var done: Bool = false
+
+    // id is the id of the item. This field is read-only.
+    var id: Int = 0
+
+    // labels is the labels of the to-do item, keyed by name.
+    var labels: [String: String] = [:]
+
+    // priority is the priority of the to-do item. Defaults to "normal".
+    var priority: Priority = .normal
+
+    // reminder is the reminder of the to-do item.
+    var reminder: Reminder = .unknown("")
+
+    // status is the status of the to-do item. Must be one of: "pending", "completed".
+    var status: ItemStatus = .unknown
+
+    // text is the to-do item text. This field is required. Must be at most 200 characters long.
+    var text: String = ""
+
+    // uRL is the link of the to-do item. Must be a valid URI.
+    var uRL: String? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case createdAt = "created_at"
+        case done = "done"
+        case id = "id"
+        case labels = "labels"
+        case priority = "priority"
+        case reminder = "reminder"
+        case status = "status"
+        case text = "text"
+        case uRL = "url"
+    }
+}
+
+extension Item {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let createdAt = try container.decodeIfPresent(Date.self, forKey: .createdAt) {
+            self.createdAt = createdAt
+        }
+        if let done = try container.decodeIfPresent(Bool.self, forKey: .done) {
+            self.done = done
+        }
+        if let id = try container.decodeIfPresent(Int.self, forKey: .id) {
+            self.id = id
+        }
+        if let labels = try container.decodeIfPresent([String: String].self, forKey: .labels) {
+            self.labels = labels
+        }
+        if let priority = try container.decodeIfPresent(Priority.self, forKey: .priority) {
+            self.priority = priority
+        }
+        if let reminder = try container.decodeIfPresent(Reminder.self, forKey: .reminder) {
+            self.reminder = reminder
+        }
+        if let status = try container.decodeIfPresent(ItemStatus.self, forKey: .status) {
+            self.status = status
+        }
+        if let text = try container.decodeIfPresent(String.self, forKey: .text) {
+            self.text = text
+        }
+        if let uRL = try container.decodeIfPresent(String.self, forKey: .uRL) {
+            self.uRL = uRL
+        }
+    }
+}
+
+extension Item: Validatable {
+    func validate() throws {
+        if text.count > 200 {
+            throw ValidationError(message: "text must be at most 200 characters long")
+        }
+        if let uRL = uRL, uRL.range(of: #"^[a-zA-Z][a-zA-Z0-9+.-]*:\S+$"#, options: .regularExpression) == nil {
+            throw ValidationError(message: "url must be a valid URI")
+        }
+    }
+}
+// ItemInput is a to-do item. Read-only fields are omitted.
+struct ItemInput: Codable {
+    // done is whether the to-do item is done.
+    @available(*, deprecated, message: "Use status instead.")
+    var done: Bool = false
+
+    // labels is the labels of the to-do item, keyed by name.
+    var labels: [String: String] = [:]
+
+    // priority is the priority of the to-do item. Defaults to "normal".
+    var priority: Priority = .normal
+
+    // reminder is the reminder of the to-do item.
+    var reminder: Reminder = .unknown("")
+
+    // status is the status of the to-do item. Must be one of: "pending", "completed".
+    var status: ItemStatus = .unknown
+
+    // text is the to-do item text. This field is required. Must be at most 200 characters long.
+    var text: String = ""
+
+    // uRL is the link of the to-do item. Must be a valid URI.
+    var uRL: String? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case done = "done"
+        case labels = "labels"
+        case priority = "priority"
+        case reminder = "reminder"
+        case status = "status"
+        case text = "text"
+        case uRL = "url"
+    }
+}
+
+extension ItemInput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let done = try container.decodeIfPresent(Bool.self, forKey: .done) {
+            self.done = done
+        }
+        if let labels = try container.decodeIfPresent([String: String].self, forKey: .labels) {
+            self.labels = labels
+        }
+        if let priority = try container.decodeIfPresent(Priority.self, forKey: .priority) {
+            self.priority = priority
+        }
+        if let reminder = try container.decodeIfPresent(Reminder.self, forKey: .reminder) {
+            self.reminder = reminder
+        }
+        if let status = try container.decodeIfPresent(ItemStatus.self, forKey: .status) {
+            self.status = status
+        }
+        if let text = try container.decodeIfPresent(String.self, forKey: .text) {
+            self.text = text
+        }
+        if let uRL = try container.decodeIfPresent(String.self, forKey: .uRL) {
+            self.uRL = uRL
+        }
+    }
+}
+
+extension ItemInput: Validatable {
+    func validate() throws {
+        if text.count > 200 {
+            throw ValidationError(message: "text must be at most 200 characters long")
+        }
+        if let uRL = uRL, uRL.range(of: #"^[a-zA-Z][a-zA-Z0-9+.-]*:\S+$"#, options: .regularExpression) == nil {
+            throw ValidationError(message: "url must be a valid URI")
+        }
+    }
+}
+// LocationReminder is a reminder when arriving at a location.
+struct LocationReminder: Codable {
+    // latitude is the latitude of the location. This field is required.
+    var latitude: Double = 0.0
+
+    // longitude is the longitude of the location. This field is required.
+    var longitude: Double = 0.0
+
+    enum CodingKeys: String, CodingKey {
+        case latitude = "latitude"
+        case longitude = "longitude"
+    }
+}
+
+extension LocationReminder {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let latitude = try container.decodeIfPresent(Double.self, forKey: .latitude) {
+            self.latitude = latitude
+        }
+        if let longitude = try container.decodeIfPresent(Double.self, forKey: .longitude) {
+            self.longitude = longitude
+        }
+    }
+}
+// Reminder is a reminder for a to-do item.
+enum Reminder: Codable {
+    case timeReminder(TimeReminder)
+    case locationReminder(LocationReminder)
+
+    // unknown is a variant not known to this version of the client.
+    case unknown(String)
+
+    enum DiscriminatorKeys: String, CodingKey {
+        case type = "type"
+    }
+
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: DiscriminatorKeys.self)
+        let variant = try container.decode(String.self, forKey: .type)
+        switch variant {
+        case "time_reminder":
+            self = .timeReminder(try TimeReminder(from: decoder))
+        case "location_reminder":
+            self = .locationReminder(try LocationReminder(from: decoder))
+        default:
+            self = .unknown(variant)
+        }
+    }
+
+    func encode(to encoder: Encoder) throws {
+        var container = encoder.container(keyedBy: DiscriminatorKeys.self)
+        switch self {
+        case .timeReminder(let value):
+            try container.encode("time_reminder", forKey: .type)
+            try value.encode(to: encoder)
+        case .locationReminder(let value):
+            try container.encode("location_reminder", forKey: .type)
+            try value.encode(to: encoder)
+        case .unknown(let variant):
+            try container.encode(variant, forKey: .type)
+        }
+    }
+}
+
+// Stats is the statistics of a to-do list.
+struct Stats: Codable {
+    // completed is the number of completed items.
+    var completed: Int = 0
+
+    // total is the number of items.
+    var total: Int = 0
+
+    enum CodingKeys: String, CodingKey {
+        case completed = "completed"
+        case total = "total"
+    }
+}
+
+extension Stats {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let completed = try container.decodeIfPresent(Int.self, forKey: .completed) {
+            self.completed = completed
+        }
+        if let total = try container.decodeIfPresent(Int.self, forKey: .total) {
+            self.total = total
+        }
+    }
+}
+// TimeReminder is a reminder at a point in time.
+struct TimeReminder: Codable {
+    // at is the time to remind at. This field is required.
+    var at: Date = Date()
+
+    enum CodingKeys: String, CodingKey {
+        case at = "at"
+    }
+}
+
+extension TimeReminder {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let at = try container.decodeIfPresent(Date.self, forKey: .at) {
+            self.at = at
+        }
+    }
+}
+// AddItemInput params.
+struct AddItemInput: Codable {
+    // item is the item to add. This field is required. Must be at least 1 character long. Must be at most 200 characters long.
+    var item: String = ""
+
+    // priority is the priority of the item. Defaults to "normal".
+    var priority: Priority = .normal
+
+    enum CodingKeys: String, CodingKey {
+        case item = "item"
+        case priority = "priority"
+    }
+}
+
+extension AddItemInput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let item = try container.decodeIfPresent(String.self, forKey: .item) {
+            self.item = item
+        }
+        if let priority = try container.decodeIfPresent(Priority.self, forKey: .priority) {
+            self.priority = priority
+        }
+    }
+}
+
+extension AddItemInput: Validatable {
+    func validate() throws {
+        if item.count < 1 {
+            throw ValidationError(message: "item must be at least 1 character long")
+        }
+        if item.count > 200 {
+            throw ValidationError(message: "item must be at most 200 characters long")
+        }
+    }
+}
+
+// GetItemsOutput params.
+struct GetItemsOutput: Codable {
+    // items is the list of to-do items.
+    var items: [Item] = []
+
+    // lists is the to-do items grouped by list name.
+    var lists: [String: [Item]] = [:]
+
+    enum CodingKeys: String, CodingKey {
+        case items = "items"
+        case lists = "lists"
+    }
+}
+
+extension GetItemsOutput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let items = try container.decodeIfPresent([Item].self, forKey: .items) {
+            self.items = items
+        }
+        if let lists = try container.decodeIfPresent([String: [Item]].self, forKey: .lists) {
+            self.lists = lists
+        }
+    }
+}
+
+// GetStatsOutput params.
+struct GetStatsOutput: Codable {
+    // stats is the statistics.
+    var stats: Stats = Stats()
+
+    enum CodingKeys: String, CodingKey {
+        case stats = "stats"
+    }
+}
+
+extension GetStatsOutput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let stats = try container.decodeIfPresent(Stats.self, forKey: .stats) {
+            self.stats = stats
+        }
+    }
+}
+
+// RemoveItemInput params.
+@available(*, deprecated, message: "Set the item status to completed instead. Removal is scheduled for 2027-06-01.")
+struct RemoveItemInput: Codable {
+    // id is the id of the item to remove. Must be at least 1.
+    var id: Int? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case id = "id"
+    }
+}
+
+extension RemoveItemInput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let id = try container.decodeIfPresent(Int.self, forKey: .id) {
+            self.id = id
+        }
+    }
+}
+
+extension RemoveItemInput: Validatable {
+    func validate() throws {
+        if let id = id, id < 1 {
+            throw ValidationError(message: "id must be at least 1")
+        }
+    }
+}
+
+// RemoveItemOutput params.
+@available(*, deprecated, message: "Set the item status to completed instead. Removal is scheduled for 2027-06-01.")
+struct RemoveItemOutput: Codable {
+    // item is the item removed.
+    var item: Item? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case item = "item"
+    }
+}
+
+extension RemoveItemOutput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let item = try container.decodeIfPresent(Item.self, forKey: .item) {
+            self.item = item
+        }
+    }
+}
+
+// UpdateItemInput params.
+struct UpdateItemInput: Codable {
+    // id is the id of the item to update. This field is required. Must be at least 1.
+    var id: Int = 0
+
+    // item is the updated item. This field is required.
+    var item: ItemInput = ItemInput()
+
+    enum CodingKeys: String, CodingKey {
+        case id = "id"
+        case item = "item"
+    }
+}
+
+extension UpdateItemInput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let id = try container.decodeIfPresent(Int.self, forKey: .id) {
+            self.id = id
+        }
+        if let item = try container.decodeIfPresent(ItemInput.self, forKey: .item) {
+            self.item = item
+        }
+    }
+}
+
+extension UpdateItemInput: Validatable {
+    func validate() throws {
+        if id < 1 {
+            throw ValidationError(message: "id must be at least 1")
+        }
+        try item.validate()
+    }
+}
+
+// UpdateItemOutput params.
+struct UpdateItemOutput: Codable {
+    // item is the item updated.
+    var item: Item? = nil
+
+    enum CodingKeys: String, CodingKey {
+        case item = "item"
+    }
+}
+
+extension UpdateItemOutput {
+    init(from decoder: Decoder) throws {
+        let container = try decoder.container(keyedBy: CodingKeys.self)
+        if let item = try container.decodeIfPresent(Item.self, forKey: .item) {
+            self.item = item
+        }
+    }
+}
+
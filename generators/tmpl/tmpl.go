@@ -0,0 +1,64 @@
+// Package tmpl renders schemas through text/template, for one-off outputs
+// such as wiki pages or configuration snippets.
+package tmpl
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/template"
+
+	"github.com/newlix/rpc/generators/gotypes"
+	"github.com/newlix/rpc/generators/kotlintypes"
+	"github.com/newlix/rpc/generators/swifttypes"
+	"github.com/newlix/rpc/generators/tstypes"
+	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Funcs returns the template functions of schema s:
+//
+//   - GoName, JsName and ID format names, such as AddItem, addItem and add_item
+//   - FormatExtra and FormatEnum describe the constraints and enum values of a field
+//   - ResolveRef returns the type of a reference
+//   - GoType, TSType, SwiftType and KotlinType return the type of a field in each language
+func Funcs(s *schema.Schema) template.FuncMap {
+	return template.FuncMap{
+		"GoName":      format.GoName,
+		"JsName":      format.JsName,
+		"ID":          format.ID,
+		"FormatExtra": schemautil.FormatExtra,
+		"FormatEnum":  schemautil.FormatEnum,
+		"ResolveRef": func(ref schema.Ref) schema.Type {
+			return schemautil.ResolveRef(s, ref)
+		},
+		"GoType": func(f schema.Field) string {
+			return gotypes.Type(s, f)
+		},
+		"TSType": func(f schema.Field) string {
+			return tstypes.Type(s, f)
+		},
+		"SwiftType": func(f schema.Field) string {
+			return swifttypes.Type(s, f)
+		},
+		"KotlinType": func(f schema.Field) string {
+			return kotlintypes.Type(s, f)
+		},
+	}
+}
+
+// Generate writes schema s rendered through the template at path to w.
+func Generate(w io.Writer, s *schema.Schema, path string) error {
+	t, err := template.New(filepath.Base(path)).Funcs(Funcs(s)).ParseFiles(path)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+
+	err = t.Execute(w, s)
+	if err != nil {
+		return fmt.Errorf("rendering template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package tmpl_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+	"github.com/tj/go-fixture"
+
+	"github.com/newlix/rpc/generators/tmpl"
+	"github.com/newlix/rpc/schema"
+)
+
+func TestGenerate(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tmpl.Generate(&act, schema, "testdata/methods.md.tmpl")
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_methods.md", act.Bytes())
+}
+
+func TestGenerate_errors(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	path := filepath.Join(t.TempDir(), "invalid.tmpl")
+
+	t.Run("parsing", func(t *testing.T) {
+		err := os.WriteFile(path, []byte("{{range .Methods}}"), 0644)
+		assert.NoError(t, err)
+
+		err = tmpl.Generate(&bytes.Buffer{}, schema, path)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "parsing template: ")
+	})
+
+	t.Run("rendering", func(t *testing.T) {
+		err := os.WriteFile(path, []byte("{{.Missing}}"), 0644)
+		assert.NoError(t, err)
+
+		err = tmpl.Generate(&bytes.Buffer{}, schema, path)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "rendering template: ")
+	})
+}
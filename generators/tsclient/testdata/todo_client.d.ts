@@ -0,0 +1,97 @@
+import type { AddItemInput, GetItemsOutput, GetStatsOutput, RemoveItemInput, RemoveItemOutput, UpdateItemInput, UpdateItemOutput } from './types.js'
+
+/**
+ * FetchResponse is the response of a Fetch.
+ */
+
+export interface FetchResponse {
+  status: number
+  statusText: string
+  json(): Promise<any>
+  text(): Promise<string>
+}
+
+/**
+ * Fetch is the fetch function used by clients, such as the global fetch or node-fetch.
+ */
+
+export type Fetch = (url: string, init: { method: string, body: string, headers: Record<string, string> }) => Promise<FetchResponse>
+
+/**
+ * ClientError is an API client error providing the HTTP status code and error type.
+ */
+
+export declare class ClientError extends Error {
+  status: number;
+  type?: string;
+
+  constructor(status: number, message?: string, type?: string)
+}
+
+/**
+ * ItemsClient is the client of the items group.
+ *
+ * The items group provides methods for adding, listing and removing to-do items.
+ */
+
+export declare class ItemsClient {
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params: { url: string, authToken?: string, fetch?: Fetch })
+
+  /**
+   * addItem: adds an item to the list.
+   */
+
+  addItem(params: AddItemInput): Promise<void>
+
+  /**
+   * getItems: returns all items in the list.
+   */
+
+  getItems(): Promise<GetItemsOutput>
+
+  /**
+   * removeItem: removes an item from the to-do list.
+   *
+   * @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+   */
+
+  removeItem(params: RemoveItemInput): Promise<RemoveItemOutput>
+
+  /**
+   * updateItem: updates an item in the to-do list.
+   */
+
+  updateItem(params: UpdateItemInput): Promise<UpdateItemOutput>
+
+}
+
+/**
+ * Client is the API client.
+ */
+
+export declare class Client {
+
+  /**
+   * items: Manage the items of the to-do list.
+   */
+
+  readonly items: ItemsClient
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params: { url: string, authToken?: string, fetch?: Fetch })
+
+  /**
+   * getStats: returns statistics of the to-do list, for administrators.
+   */
+
+  getStats(): Promise<GetStatsOutput>
+
+}
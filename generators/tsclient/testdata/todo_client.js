@@ -0,0 +1,143 @@
+import { validateAddItemInput, decodeGetItemsOutput, validateRemoveItemInput, decodeRemoveItemOutput, validateUpdateItemInput, encodeUpdateItemInput, decodeUpdateItemOutput } from './types.js'
+
+// defaultFetch calls the global fetch, unless clients are given a fetch.
+const defaultFetch = (url, init) => globalThis.fetch(url, init)
+
+/**
+ * ClientError is an API client error providing the HTTP status code and error type.
+ */
+
+export class ClientError extends Error {
+  constructor(status, message, type) {
+    super(message)
+    this.status = status
+    this.type = type
+  }
+}
+
+/**
+ * Call method with params via a POST request.
+ */
+
+async function call(fetch, url, method, authToken, params) {
+  const headers = {
+    'Content-Type': 'application/json'
+  }
+
+  if (authToken != null) {
+    headers['Authorization'] = `Bearer ${authToken}`
+  }
+
+  const res = await fetch(url + '/' + method, {
+    method: 'POST',
+    body: JSON.stringify(params),
+    headers
+  })
+
+  // we have an error, try to parse a well-formed json
+  // error response, otherwise default to status code
+  if (res.status >= 300) {
+    let err
+    try {
+      const { type, message } = await res.json()
+      err = new ClientError(res.status, message, type)
+    } catch {
+      err = new ClientError(res.status, res.statusText)
+    }
+    throw err
+  }
+
+  return res.text()
+}
+
+/**
+ * ItemsClient is the client of the items group.
+ *
+ * The items group provides methods for adding, listing and removing to-do items.
+ */
+
+export class ItemsClient {
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params) {
+    this.url = params.url
+    this.authToken = params.authToken
+    this.fetch = params.fetch ?? defaultFetch
+  }
+
+  /**
+   * addItem: adds an item to the list.
+   */
+
+  async addItem(params) {
+    validateAddItemInput(params)
+    await call(this.fetch, this.url, 'add_item', this.authToken, params)
+  }
+
+  /**
+   * getItems: returns all items in the list.
+   */
+
+  async getItems() {
+    let res = await call(this.fetch, this.url, 'get_items', this.authToken)
+    let out = decodeGetItemsOutput(JSON.parse(res))
+    return out
+  }
+
+  /**
+   * removeItem: removes an item from the to-do list.
+   *
+   * @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+   */
+
+  async removeItem(params) {
+    validateRemoveItemInput(params)
+    let res = await call(this.fetch, this.url, 'remove_item', this.authToken, params)
+    let out = decodeRemoveItemOutput(JSON.parse(res))
+    return out
+  }
+
+  /**
+   * updateItem: updates an item in the to-do list.
+   */
+
+  async updateItem(params) {
+    validateUpdateItemInput(params)
+    let res = await call(this.fetch, this.url, 'update_item', this.authToken, encodeUpdateItemInput(params))
+    let out = decodeUpdateItemOutput(JSON.parse(res))
+    return out
+  }
+
+}
+
+/**
+ * Client is the API client.
+ */
+
+export class Client {
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params) {
+    this.url = params.url
+    this.authToken = params.authToken
+    this.fetch = params.fetch ?? defaultFetch
+    this.items = new ItemsClient(params)
+  }
+
+  /**
+   * getStats: returns statistics of the to-do list, for administrators.
+   */
+
+  async getStats() {
+    let res = await call(this.fetch, this.url, 'get_stats', this.authToken)
+    let out = JSON.parse(res)
+    return out
+  }
+
+}
@@ -1,15 +1,29 @@
 
-// fetch for Node
-const fetch = (typeof window == 'undefined' || window.fetch == null)
-// @ts-ignore
-  ? require('node-fetch')
-  : window.fetch
+/**
+ * FetchResponse is the response of a Fetch.
+ */
+
+export interface FetchResponse {
+  status: number
+  statusText: string
+  json(): Promise<any>
+  text(): Promise<string>
+}
+
+/**
+ * Fetch is the fetch function used by clients, such as the global fetch or node-fetch.
+ */
+
+export type Fetch = (url: string, init: { method: string, body: string, headers: Record<string, string> }) => Promise<FetchResponse>
+
+// defaultFetch calls the global fetch, unless clients are given a fetch.
+const defaultFetch: Fetch = (url, init) => (globalThis as any).fetch(url, init)
 
 /**
  * ClientError is an API client error providing the HTTP status code and error type.
  */
 
-class ClientError extends Error {
+export class ClientError extends Error {
   status: number;
   type?: string;
 
@@ -24,15 +38,15 @@ class ClientError extends Error {
  * Call method with params via a POST request.
  */
 
-async function call(url: string, method: string, authToken?: string, params?: any): Promise<string> {
+async function call(fetch: Fetch, url: string, method: string, authToken?: string, params?: any): Promise<string> {
   const headers: Record<string, string> = {
     'Content-Type': 'application/json'
   }
-  
+
   if (authToken != null) {
     headers['Authorization'] = `Bearer ${authToken}`
   }
-  
+
   const res = await fetch(url + '/' + method, {
     method: 'POST',
     body: JSON.stringify(params),
@@ -55,35 +69,26 @@ async function call(url: string, method: string, authToken?: string, params?: an
   return res.text()
 }
 
-
-const reISO8601 = /(\d{4}-[01]\d-[0-3]\dT[0-2]\d:[0-5]\d:[0-5]\d\.\d+([+-][0-2]\d:[0-5]\d|Z))|(\d{4}-[01]\d-[0-3]\dT[0-2]\d:[0-5]\d:[0-5]\d([+-][0-2]\d:[0-5]\d|Z))|(\d{4}-[01]\d-[0-3]\dT[0-2]\d:[0-5]\d([+-][0-2]\d:[0-5]\d|Z))/
-
 /**
- * Client is the API client.
+ * ItemsClient is the client of the items group.
+ *
+ * The items group provides methods for adding, listing and removing to-do items.
  */
 
-export class Client {
+export class ItemsClient {
 
   private url: string
   private authToken?: string
+  private fetch: Fetch
 
   /**
    * Initialize.
    */
 
-  constructor(params: { url: string, authToken?: string }) {
+  constructor(params: { url: string, authToken?: string, fetch?: Fetch }) {
     this.url = params.url
     this.authToken = params.authToken
-  }
-
-  /**
-   * Decoder is used as the reviver parameter when decoding responses.
-   */
-
-  private decoder(key: any, value: any) {
-    return typeof value == 'string' && reISO8601.test(value)
-      ? new Date(value)
-      : value
+    this.fetch = params.fetch ?? defaultFetch
   }
 
   /**
@@ -91,7 +96,8 @@ export class Client {
    */
 
   async addItem(params: AddItemInput) {
-    await call(this.url, 'add_item', this.authToken, params)
+    validateAddItemInput(params)
+    await call(this.fetch, this.url, 'add_item', this.authToken, params)
   }
 
   /**
@@ -99,18 +105,71 @@ export class Client {
    */
 
   async getItems(): Promise<GetItemsOutput> {
-    let res = await call(this.url, 'get_items', this.authToken)
-    let out: GetItemsOutput = JSON.parse(res, this.decoder)
+    let res = await call(this.fetch, this.url, 'get_items', this.authToken)
+    let out: GetItemsOutput = decodeGetItemsOutput(JSON.parse(res))
     return out
   }
 
   /**
    * removeItem: removes an item from the to-do list.
+   *
+   * @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01.
    */
 
   async removeItem(params: RemoveItemInput): Promise<RemoveItemOutput> {
-    let res = await call(this.url, 'remove_item', this.authToken, params)
-    let out: RemoveItemOutput = JSON.parse(res, this.decoder)
+    validateRemoveItemInput(params)
+    let res = await call(this.fetch, this.url, 'remove_item', this.authToken, params)
+    let out: RemoveItemOutput = decodeRemoveItemOutput(JSON.parse(res))
+    return out
+  }
+
+  /**
+   * updateItem: updates an item in the to-do list.
+   */
+
+  async updateItem(params: UpdateItemInput): Promise<UpdateItemOutput> {
+    validateUpdateItemInput(params)
+    let res = await call(this.fetch, this.url, 'update_item', this.authToken, encodeUpdateItemInput(params))
+    let out: UpdateItemOutput = decodeUpdateItemOutput(JSON.parse(res))
+    return out
+  }
+
+}
+
+/**
+ * Client is the API client.
+ */
+
+export class Client {
+
+  private url: string
+  private authToken?: string
+  private fetch: Fetch
+
+  /**
+   * items: Manage the items of the to-do list.
+   */
+
+  readonly items: ItemsClient
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params: { url: string, authToken?: string, fetch?: Fetch }) {
+    this.url = params.url
+    this.authToken = params.authToken
+    this.fetch = params.fetch ?? defaultFetch
+    this.items = new ItemsClient(params)
+  }
+
+  /**
+   * getStats: returns statistics of the to-do list, for administrators.
+   */
+
+  async getStats(): Promise<GetStatsOutput> {
+    let res = await call(this.fetch, this.url, 'get_stats', this.authToken)
+    let out: GetStatsOutput = JSON.parse(res)
     return out
   }
 
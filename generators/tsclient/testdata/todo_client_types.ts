@@ -0,0 +1,179 @@
+import type { AddItemInput, GetItemsOutput, GetStatsOutput, RemoveItemInput, RemoveItemOutput, UpdateItemInput, UpdateItemOutput } from './types'
+import { validateAddItemInput, decodeGetItemsOutput, validateRemoveItemInput, decodeRemoveItemOutput, validateUpdateItemInput, encodeUpdateItemInput, decodeUpdateItemOutput } from './types'
+import libraryFetch from 'node-fetch'
+
+/**
+ * FetchResponse is the response of a Fetch.
+ */
+
+export interface FetchResponse {
+  status: number
+  statusText: string
+  json(): Promise<any>
+  text(): Promise<string>
+}
+
+/**
+ * Fetch is the fetch function used by clients, such as the global fetch or node-fetch.
+ */
+
+export type Fetch = (url: string, init: { method: string, body: string, headers: Record<string, string> }) => Promise<FetchResponse>
+
+// defaultFetch calls the fetch library, unless clients are given a fetch.
+const defaultFetch: Fetch = (url, init) => (libraryFetch as any)(url, init)
+
+/**
+ * ClientError is an API client error providing the HTTP status code and error type.
+ */
+
+export class ClientError extends Error {
+  status: number;
+  type?: string;
+
+  constructor(status: number, message?: string, type?: string) {
+    super(message)
+    this.status = status
+    this.type = type
+  }
+}
+
+/**
+ * Call method with params via a POST request.
+ */
+
+async function call(fetch: Fetch, url: string, method: string, authToken?: string, params?: any): Promise<string> {
+  const headers: Record<string, string> = {
+    'Content-Type': 'application/json'
+  }
+
+  if (authToken != null) {
+    headers['Authorization'] = `Bearer ${authToken}`
+  }
+
+  const res = await fetch(url + '/' + method, {
+    method: 'POST',
+    body: JSON.stringify(params),
+    headers
+  })
+
+  // we have an error, try to parse a well-formed json
+  // error response, otherwise default to status code
+  if (res.status >= 300) {
+    let err
+    try {
+      const { type, message } = await res.json()
+      err = new ClientError(res.status, message, type)
+    } catch {
+      err = new ClientError(res.status, res.statusText)
+    }
+    throw err
+  }
+
+  return res.text()
+}
+
+/**
+ * ItemsClient is the client of the items group.
+ *
+ * The items group provides methods for adding, listing and removing to-do items.
+ */
+
+export class ItemsClient {
+
+  private url: string
+  private authToken?: string
+  private fetch: Fetch
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params: { url: string, authToken?: string, fetch?: Fetch }) {
+    this.url = params.url
+    this.authToken = params.authToken
+    this.fetch = params.fetch ?? defaultFetch
+  }
+
+  /**
+   * addItem: adds an item to the list.
+   */
+
+  async addItem(params: AddItemInput) {
+    validateAddItemInput(params)
+    await call(this.fetch, this.url, 'add_item', this.authToken, params)
+  }
+
+  /**
+   * getItems: returns all items in the list.
+   */
+
+  async getItems(): Promise<GetItemsOutput> {
+    let res = await call(this.fetch, this.url, 'get_items', this.authToken)
+    let out: GetItemsOutput = decodeGetItemsOutput(JSON.parse(res))
+    return out
+  }
+
+  /**
+   * removeItem: removes an item from the to-do list.
+   *
+   * @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01.
+   */
+
+  async removeItem(params: RemoveItemInput): Promise<RemoveItemOutput> {
+    validateRemoveItemInput(params)
+    let res = await call(this.fetch, this.url, 'remove_item', this.authToken, params)
+    let out: RemoveItemOutput = decodeRemoveItemOutput(JSON.parse(res))
+    return out
+  }
+
+  /**
+   * updateItem: updates an item in the to-do list.
+   */
+
+  async updateItem(params: UpdateItemInput): Promise<UpdateItemOutput> {
+    validateUpdateItemInput(params)
+    let res = await call(this.fetch, this.url, 'update_item', this.authToken, encodeUpdateItemInput(params))
+    let out: UpdateItemOutput = decodeUpdateItemOutput(JSON.parse(res))
+    return out
+  }
+
+}
+
+/**
+ * Client is the API client.
+ */
+
+export class Client {
+
+  private url: string
+  private authToken?: string
+  private fetch: Fetch
+
+  /**
+   * items: Manage the items of the to-do list.
+   */
+
+  readonly items: ItemsClient
+
+  /**
+   * Initialize.
+   */
+
+  constructor(params: { url: string, authToken?: string, fetch?: Fetch }) {
+    this.url = params.url
+    this.authToken = params.authToken
+    this.fetch = params.fetch ?? defaultFetch
+    this.items = new ItemsClient(params)
+  }
+
+  /**
+   * getStats: returns statistics of the to-do list, for administrators.
+   */
+
+  async getStats(): Promise<GetStatsOutput> {
+    let res = await call(this.fetch, this.url, 'get_stats', this.authToken)
+    let out: GetStatsOutput = JSON.parse(res)
+    return out
+  }
+
+}
@@ -3,24 +3,38 @@ package tsclient
 import (
 	"fmt"
 	"io"
+	"path"
+	"strings"
 
+	"github.com/newlix/rpc/generators/tstypes"
 	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
 	"github.com/newlix/rpc/schema"
 )
 
-var require = `
-// fetch for Node
-const fetch = (typeof window == 'undefined' || window.fetch == null)
-// @ts-ignore
-  ? require('%s')
-  : window.fetch
+var fetchTypes = `/**
+ * FetchResponse is the response of a Fetch.
+ */
+
+export interface FetchResponse {
+  status: number
+  statusText: string
+  json(): Promise<any>
+  text(): Promise<string>
+}
+
+/**
+ * Fetch is the fetch function used by clients, such as the global fetch or node-fetch.
+ */
+
+export type Fetch = (url: string, init: { method: string, body: string, headers: Record<string, string> }) => Promise<FetchResponse>
 `
 
-var call = `/**
+var callTS = `/**
  * ClientError is an API client error providing the HTTP status code and error type.
  */
 
-class ClientError extends Error {
+export class ClientError extends Error {
   status: number;
   type?: string;
 
@@ -35,15 +49,62 @@ class ClientError extends Error {
  * Call method with params via a POST request.
  */
 
-async function call(url: string, method: string, authToken?: string, params?: any): Promise<string> {
+async function call(fetch: Fetch, url: string, method: string, authToken?: string, params?: any): Promise<string> {
   const headers: Record<string, string> = {
     'Content-Type': 'application/json'
   }
-  
+
+  if (authToken != null) {
+    headers['Authorization'] = ` + "`Bearer ${authToken}`" + `
+  }
+
+  const res = await fetch(url + '/' + method, {
+    method: 'POST',
+    body: JSON.stringify(params),
+    headers
+  })
+
+  // we have an error, try to parse a well-formed json
+  // error response, otherwise default to status code
+  if (res.status >= 300) {
+    let err
+    try {
+      const { type, message } = await res.json()
+      err = new ClientError(res.status, message, type)
+    } catch {
+      err = new ClientError(res.status, res.statusText)
+    }
+    throw err
+  }
+
+  return res.text()
+}`
+
+var callJS = `/**
+ * ClientError is an API client error providing the HTTP status code and error type.
+ */
+
+export class ClientError extends Error {
+  constructor(status, message, type) {
+    super(message)
+    this.status = status
+    this.type = type
+  }
+}
+
+/**
+ * Call method with params via a POST request.
+ */
+
+async function call(fetch, url, method, authToken, params) {
+  const headers = {
+    'Content-Type': 'application/json'
+  }
+
   if (authToken != null) {
     headers['Authorization'] = ` + "`Bearer ${authToken}`" + `
   }
-  
+
   const res = await fetch(url + '/' + method, {
     method: 'POST',
     body: JSON.stringify(params),
@@ -66,89 +127,303 @@ async function call(url: string, method: string, authToken?: string, params?: an
   return res.text()
 }`
 
-// Generate writes the TS client implementations to w.
-func Generate(w io.Writer, s *schema.Schema, fetchLibrary string) error {
+var callDeclarations = `/**
+ * ClientError is an API client error providing the HTTP status code and error type.
+ */
+
+export declare class ClientError extends Error {
+  status: number;
+  type?: string;
+
+  constructor(status: number, message?: string, type?: string)
+}
+`
+
+// Generate writes the TS client implementations to w, as an ES module of the
+// given mode. Clients use the default export of the module fetchLibrary as
+// fetch unless given one, or the global fetch when empty. The types are
+// imported from the module types, or expected in the same module when empty.
+func Generate(w io.Writer, s *schema.Schema, fetchLibrary, types string, mode tstypes.Mode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+
+	s = schemautil.Inputs(s)
 	out := fmt.Fprintf
 
-	out(w, require, fetchLibrary)
-	out(w, "\n%s\n", call)
-	out(w, "\n\n")
-	out(w, `const reISO8601 = /(\d{4}-[01]\d-[0-3]\dT[0-2]\d:[0-5]\d:[0-5]\d\.\d+([+-][0-2]\d:[0-5]\d|Z))|(\d{4}-[01]\d-[0-3]\dT[0-2]\d:[0-5]\d:[0-5]\d([+-][0-2]\d:[0-5]\d|Z))|(\d{4}-[01]\d-[0-3]\dT[0-2]\d:[0-5]\d([+-][0-2]\d:[0-5]\d|Z))/`)
-	out(w, "\n\n")
+	// imports
+	if types != "" {
+		writeImports(w, s, module(types, mode), mode)
+	}
+
+	if fetchLibrary != "" && mode != tstypes.Declarations {
+		out(w, "import libraryFetch from '%s'\n", fetchLibrary)
+	}
+
+	out(w, "\n")
+
+	// fetch
+	if mode != tstypes.JS {
+		out(w, "%s\n", fetchTypes)
+	}
+
+	switch {
+	case mode == tstypes.Declarations:
+	case fetchLibrary != "" && mode == tstypes.JS:
+		out(w, "// defaultFetch calls the fetch library, unless clients are given a fetch.\n")
+		out(w, "const defaultFetch = (url, init) => libraryFetch(url, init)\n\n")
+	case fetchLibrary != "":
+		out(w, "// defaultFetch calls the fetch library, unless clients are given a fetch.\n")
+		out(w, "const defaultFetch: Fetch = (url, init) => (libraryFetch as any)(url, init)\n\n")
+	case mode == tstypes.JS:
+		out(w, "// defaultFetch calls the global fetch, unless clients are given a fetch.\n")
+		out(w, "const defaultFetch = (url, init) => globalThis.fetch(url, init)\n\n")
+	default:
+		out(w, "// defaultFetch calls the global fetch, unless clients are given a fetch.\n")
+		out(w, "const defaultFetch: Fetch = (url, init) => (globalThis as any).fetch(url, init)\n\n")
+	}
+
+	// call
+	switch mode {
+	case tstypes.Declarations:
+		out(w, "%s\n", callDeclarations)
+	case tstypes.JS:
+		out(w, "%s\n\n", callJS)
+	default:
+		out(w, "%s\n\n", callTS)
+	}
+
+	// groups
+	for _, g := range schemautil.Groups(s) {
+		out(w, "/**\n")
+		out(w, " * %sClient is the client of the %s group.\n", format.GoName(g.Name), g.Name)
+		out(w, " *\n")
+		out(w, " * %s\n", g.Description)
+		out(w, " */\n")
+		out(w, "\n")
+		if mode == tstypes.Declarations {
+			writeDeclaration(w, format.GoName(g.Name)+"Client", schemautil.GroupMethods(s, g.Name), nil)
+		} else {
+			writeClass(w, s, mode, format.GoName(g.Name)+"Client", schemautil.GroupMethods(s, g.Name), nil)
+		}
+		out(w, "\n")
+	}
+
 	out(w, "/**\n")
 	out(w, " * Client is the API client.\n")
 	out(w, " */\n")
 	out(w, "\n")
-	out(w, "export class Client {\n")
-	out(w, "\n")
-	out(w, "  private url: string\n")
-	out(w, "  private authToken?: string\n")
+	if mode == tstypes.Declarations {
+		writeDeclaration(w, "Client", schemautil.GroupMethods(s, ""), schemautil.Groups(s))
+	} else {
+		writeClass(w, s, mode, "Client", schemautil.GroupMethods(s, ""), schemautil.Groups(s))
+	}
+
+	return nil
+}
+
+// module returns the specifier of the module types imported in the given
+// mode. Relative specifiers of JavaScript and its declarations are given the
+// .js extension, which ES modules of Node.js require.
+func module(types string, mode tstypes.Mode) string {
+	relative := strings.HasPrefix(types, "./") || strings.HasPrefix(types, "../")
+	if mode == tstypes.TS || !relative || path.Ext(types) != "" {
+		return types
+	}
+	return types + ".js"
+}
+
+// writeImports writes the imports of the method types, and of the validation
+// and timestamp functions used by the client from the module types to w.
+func writeImports(w io.Writer, s *schema.Schema, types string, mode tstypes.Mode) {
+	var names, funcs []string
+	for _, m := range s.Methods {
+		name := format.GoName(m.Name)
+		if len(m.Inputs) > 0 {
+			names = append(names, name+"Input")
+		}
+		if len(m.Outputs) > 0 {
+			names = append(names, name+"Output")
+		}
+		if schemautil.Validates(s, m.Inputs) {
+			funcs = append(funcs, "validate"+name+"Input")
+		}
+		if schemautil.HasTimestamps(s, schemautil.TS, m.Inputs) {
+			funcs = append(funcs, "encode"+name+"Input")
+		}
+		if schemautil.HasTimestamps(s, schemautil.TS, m.Outputs) {
+			funcs = append(funcs, "decode"+name+"Output")
+		}
+	}
+
+	if len(names) > 0 && mode != tstypes.JS {
+		fmt.Fprintf(w, "import type { %s } from '%s'\n", strings.Join(names, ", "), types)
+	}
+
+	if len(funcs) > 0 && mode != tstypes.Declarations {
+		fmt.Fprintf(w, "import { %s } from '%s'\n", strings.Join(funcs, ", "), types)
+	}
+}
+
+// writeClass writes the client class name with methods to w, and the
+// sub-clients of groups as properties.
+func writeClass(w io.Writer, s *schema.Schema, mode tstypes.Mode, name string, methods []schema.Method, groups []schema.Group) {
+	out := fmt.Fprintf
+	ts := mode == tstypes.TS
+
+	out(w, "export class %s {\n", name)
 	out(w, "\n")
+	if ts {
+		out(w, "  private url: string\n")
+		out(w, "  private authToken?: string\n")
+		out(w, "  private fetch: Fetch\n")
+		out(w, "\n")
+	}
+
+	// groups
+	if ts {
+		writeGroups(w, groups)
+	}
+
 	out(w, "  /**\n")
 	out(w, "   * Initialize.\n")
 	out(w, "   */\n")
 	out(w, "\n")
-	out(w, "  constructor(params: { url: string, authToken?: string }) {\n")
+	if ts {
+		out(w, "  constructor(params: { url: string, authToken?: string, fetch?: Fetch }) {\n")
+	} else {
+		out(w, "  constructor(params) {\n")
+	}
 	out(w, "    this.url = params.url\n")
 	out(w, "    this.authToken = params.authToken\n")
-	out(w, "  }\n")
-	out(w, "\n")
-	out(w, "  /**\n")
-	out(w, "   * Decoder is used as the reviver parameter when decoding responses.\n")
-	out(w, "   */\n")
-	out(w, "\n")
-	out(w, "  private decoder(key: any, value: any) {\n")
-	out(w, "    return typeof value == 'string' && reISO8601.test(value)\n")
-	out(w, "      ? new Date(value)\n")
-	out(w, "      : value\n")
+	out(w, "    this.fetch = params.fetch ?? defaultFetch\n")
+	for _, g := range groups {
+		out(w, "    this.%s = new %sClient(params)\n", format.JsName(g.Name), format.GoName(g.Name))
+	}
 	out(w, "  }\n")
 	out(w, "\n")
 
 	// methods
-	for _, m := range s.Methods {
+	for _, m := range methods {
 		name := format.JsName(m.Name)
-		out(w, "  /**\n")
-		out(w, "   * %s: %s\n", name, m.Description)
-		out(w, "   */\n\n")
+		writeMethodDoc(w, m)
+		out(w, "\n")
 
 		// input
-		if len(m.Inputs) > 0 {
-			out(w, "  async %s(params: %sInput)", name, format.GoName(m.Name))
-		} else {
+		switch {
+		case len(m.Inputs) == 0:
 			out(w, "  async %s()", name)
+		case ts:
+			out(w, "  async %s(params: %sInput)", name, format.GoName(m.Name))
+		default:
+			out(w, "  async %s(params)", name)
 		}
 
 		// output
-		if len(m.Outputs) > 0 {
+		if len(m.Outputs) > 0 && ts {
 			out(w, ": Promise<%sOutput> {\n", format.GoName(m.Name))
 		} else {
 			out(w, " {\n")
 		}
 
+		// validate
+		if schemautil.Validates(s, m.Inputs) {
+			out(w, "    validate%sInput(params)\n", format.GoName(m.Name))
+		}
+
+		// call
+		args := fmt.Sprintf("this.fetch, this.url, '%s', this.authToken", m.Name)
+		switch {
+		case schemautil.HasTimestamps(s, schemautil.TS, m.Inputs):
+			args += fmt.Sprintf(", encode%sInput(params)", format.GoName(m.Name))
+		case len(m.Inputs) > 0:
+			args += ", params"
+		}
+
+		// decode
+		res := "JSON.parse(res)"
+		if schemautil.HasTimestamps(s, schemautil.TS, m.Outputs) {
+			res = fmt.Sprintf("decode%sOutput(%s)", format.GoName(m.Name), res)
+		}
+
 		// return
 		if len(m.Outputs) > 0 {
-			out(w, "    let res = ")
-			// call
-			if len(m.Inputs) > 0 {
-				out(w, "await call(this.url, '%s', this.authToken, params)\n", m.Name)
+			out(w, "    let res = await call(%s)\n", args)
+			if ts {
+				out(w, "    let out: %sOutput = %s\n", format.GoName(m.Name), res)
 			} else {
-				out(w, "await call(this.url, '%s', this.authToken)\n", m.Name)
+				out(w, "    let out = %s\n", res)
 			}
-			out(w, "    let out: %sOutput = JSON.parse(res, this.decoder)\n", format.GoName(m.Name))
 			out(w, "    return out\n")
 		} else {
-			// call
-			if len(m.Inputs) > 0 {
-				out(w, "    await call(this.url, '%s', this.authToken, params)\n", m.Name)
-			} else {
-				out(w, "    await call(this.url, '%s', this.authToken)\n", m.Name)
-			}
+			out(w, "    await call(%s)\n", args)
 		}
 
 		out(w, "  }\n\n")
 	}
 
 	out(w, "}\n")
+}
 
-	return nil
+// writeDeclaration writes the declaration of the client class name with
+// methods to w, and the sub-clients of groups as properties.
+func writeDeclaration(w io.Writer, name string, methods []schema.Method, groups []schema.Group) {
+	out := fmt.Fprintf
+	out(w, "export declare class %s {\n", name)
+	out(w, "\n")
+
+	// groups
+	writeGroups(w, groups)
+
+	out(w, "  /**\n")
+	out(w, "   * Initialize.\n")
+	out(w, "   */\n")
+	out(w, "\n")
+	out(w, "  constructor(params: { url: string, authToken?: string, fetch?: Fetch })\n")
+	out(w, "\n")
+
+	// methods
+	for _, m := range methods {
+		writeMethodDoc(w, m)
+		out(w, "\n")
+
+		var params string
+		if len(m.Inputs) > 0 {
+			params = fmt.Sprintf("params: %sInput", format.GoName(m.Name))
+		}
+
+		result := "void"
+		if len(m.Outputs) > 0 {
+			result = format.GoName(m.Name) + "Output"
+		}
+
+		out(w, "  %s(%s): Promise<%s>\n\n", format.JsName(m.Name), params, result)
+	}
+
+	out(w, "}\n")
+}
+
+// writeGroups writes the sub-clients of groups as properties to w.
+func writeGroups(w io.Writer, groups []schema.Group) {
+	out := fmt.Fprintf
+	for _, g := range groups {
+		out(w, "  /**\n")
+		out(w, "   * %s: %s\n", format.JsName(g.Name), g.Summary)
+		out(w, "   */\n")
+		out(w, "\n")
+		out(w, "  readonly %s: %sClient\n", format.JsName(g.Name), format.GoName(g.Name))
+		out(w, "\n")
+	}
+}
+
+// writeMethodDoc writes the JSDoc of method m to w.
+func writeMethodDoc(w io.Writer, m schema.Method) {
+	out := fmt.Fprintf
+	out(w, "  /**\n")
+	out(w, "   * %s: %s\n", format.JsName(m.Name), m.Description)
+	if m.Deprecated != nil {
+		out(w, "   *\n")
+		out(w, "   * @deprecated %s\n", m.Deprecated.Notice())
+	}
+	out(w, "   */\n")
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/tj/go-fixture"
 
 	"github.com/newlix/rpc/generators/tsclient"
+	"github.com/newlix/rpc/generators/tstypes"
 	"github.com/newlix/rpc/schema"
 )
 
@@ -16,8 +17,41 @@ func TestGenerate(t *testing.T) {
 	assert.NoError(t, err, "loading schema")
 
 	var act bytes.Buffer
-	err = tsclient.Generate(&act, schema, "node-fetch")
+	err = tsclient.Generate(&act, schema, "", "", tstypes.TS)
 	assert.NoError(t, err, "generating")
 
 	fixture.Assert(t, "todo_client.ts", act.Bytes())
 }
+
+func TestGenerate_types(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tsclient.Generate(&act, schema, "node-fetch", "./types", tstypes.TS)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_client_types.ts", act.Bytes())
+}
+
+func TestGenerate_js(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tsclient.Generate(&act, schema, "", "./types", tstypes.JS)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_client.js", act.Bytes())
+}
+
+func TestGenerate_declarations(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tsclient.Generate(&act, schema, "", "./types", tstypes.Declarations)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_client.d.ts", act.Bytes())
+}
@@ -0,0 +1,39 @@
+import type { Decimal } from 'decimal.js'
+
+// ValidationError is an error of params failing validation.
+export class ValidationError extends Error {}
+
+// Product is a product.
+export interface Product {
+  // colors is the colors available.
+  colors?: string[]
+
+  // discounts is the discounted prices by code.
+  discounts?: Record<string, Decimal>
+
+  // name is the name. Must be at least 1 character long.
+  name?: string
+
+  // price is the price. Must be at least 0.
+  price?: Decimal
+}
+
+// validateProduct throws a ValidationError when v is invalid.
+export function validateProduct(v: Product) {
+  if (v.name != null && Array.from(v.name).length < 1) {
+    throw new ValidationError("name must be at least 1 character long")
+  }
+}
+
+// GetProductInput params.
+export interface GetProductInput {
+  // id is the product id. This field is required.
+  id: string
+}
+
+// GetProductOutput params.
+export interface GetProductOutput {
+  // product is the product.
+  product?: Product
+}
+
@@ -0,0 +1,199 @@
+// ValidationError is an error of params failing validation.
+export declare class ValidationError extends Error {}
+
+// ItemStatus is the status of the to-do item.
+export type ItemStatus = 'pending' | 'completed'
+
+// Priority is the priority of a to-do item.
+export type Priority = 'low' | 'normal' | 'high'
+
+// Item is a to-do item.
+export interface Item {
+  // created_at is the time the to-do item was created. This field is read-only.
+  created_at?: Date
+
+  // done is whether the to-do item is done.
+  /** @deprecated Use status instead. */
+  done?: boolean
+
+  // id is the id of the item. This field is read-only.
+  id?: number
+
+  // labels is the labels of the to-do item, keyed by name.
+  labels?: Record<string, string>
+
+  // priority is the priority of the to-do item. Defaults to "normal".
+  /** @default "normal" */
+  priority?: Priority
+
+  // reminder is the reminder of the to-do item.
+  reminder?: Reminder
+
+  // status is the status of the to-do item. Must be one of: "pending", "completed".
+  status?: ItemStatus
+
+  // text is the to-do item text. This field is required. Must be at most 200 characters long.
+  text: string
+
+  // url is the link of the to-do item. Must be a valid URI.
+  url?: string
+}
+
+// validateItem throws a ValidationError when v is invalid.
+export declare function validateItem(v: Item): void
+
+// decodeItem returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeItem(v: any): Item
+
+// encodeItem returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export declare function encodeItem(v: Item): any
+
+// ItemInput is a to-do item. Read-only fields are omitted.
+export interface ItemInput {
+  // done is whether the to-do item is done.
+  /** @deprecated Use status instead. */
+  done?: boolean
+
+  // labels is the labels of the to-do item, keyed by name.
+  labels?: Record<string, string>
+
+  // priority is the priority of the to-do item. Defaults to "normal".
+  /** @default "normal" */
+  priority?: Priority
+
+  // reminder is the reminder of the to-do item.
+  reminder?: Reminder
+
+  // status is the status of the to-do item. Must be one of: "pending", "completed".
+  status?: ItemStatus
+
+  // text is the to-do item text. This field is required. Must be at most 200 characters long.
+  text: string
+
+  // url is the link of the to-do item. Must be a valid URI.
+  url?: string
+}
+
+// validateItemInput throws a ValidationError when v is invalid.
+export declare function validateItemInput(v: ItemInput): void
+
+// decodeItemInput returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeItemInput(v: any): ItemInput
+
+// encodeItemInput returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export declare function encodeItemInput(v: ItemInput): any
+
+// LocationReminder is a reminder when arriving at a location.
+export interface LocationReminder {
+  // latitude is the latitude of the location. This field is required.
+  latitude: number
+
+  // longitude is the longitude of the location. This field is required.
+  longitude: number
+}
+
+// Reminder is a reminder for a to-do item.
+export type Reminder = ({ type: 'time_reminder' } & TimeReminder) | ({ type: 'location_reminder' } & LocationReminder)
+
+// decodeReminder returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeReminder(v: any): Reminder
+
+// encodeReminder returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export declare function encodeReminder(v: Reminder): any
+
+// Stats is the statistics of a to-do list.
+export interface Stats {
+  // completed is the number of completed items.
+  completed?: number
+
+  // total is the number of items.
+  total?: number
+}
+
+// TimeReminder is a reminder at a point in time.
+export interface TimeReminder {
+  // at is the time to remind at. This field is required.
+  at: Date
+}
+
+// decodeTimeReminder returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeTimeReminder(v: any): TimeReminder
+
+// encodeTimeReminder returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export declare function encodeTimeReminder(v: TimeReminder): any
+
+// AddItemInput params.
+export interface AddItemInput {
+  // item is the item to add. This field is required. Must be at least 1 character long. Must be at most 200 characters long.
+  item: string
+
+  // priority is the priority of the item. Defaults to "normal".
+  /** @default "normal" */
+  priority?: Priority
+}
+
+// validateAddItemInput throws a ValidationError when v is invalid.
+export declare function validateAddItemInput(v: AddItemInput): void
+
+// GetItemsOutput params.
+export interface GetItemsOutput {
+  // items is the list of to-do items.
+  items?: Item[]
+
+  // lists is the to-do items grouped by list name.
+  lists?: Record<string, Item[]>
+}
+
+// decodeGetItemsOutput returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeGetItemsOutput(v: any): GetItemsOutput
+
+// GetStatsOutput params.
+export interface GetStatsOutput {
+  // stats is the statistics.
+  stats?: Stats
+}
+
+// RemoveItemInput params.
+/** @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01. */
+export interface RemoveItemInput {
+  // id is the id of the item to remove. Must be at least 1.
+  id?: number
+}
+
+// validateRemoveItemInput throws a ValidationError when v is invalid.
+export declare function validateRemoveItemInput(v: RemoveItemInput): void
+
+// RemoveItemOutput params.
+/** @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01. */
+export interface RemoveItemOutput {
+  // item is the item removed.
+  item?: Item
+}
+
+// decodeRemoveItemOutput returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeRemoveItemOutput(v: any): RemoveItemOutput
+
+// UpdateItemInput params.
+export interface UpdateItemInput {
+  // id is the id of the item to update. This field is required. Must be at least 1.
+  id: number
+
+  // item is the updated item. This field is required.
+  item: ItemInput
+}
+
+// validateUpdateItemInput throws a ValidationError when v is invalid.
+export declare function validateUpdateItemInput(v: UpdateItemInput): void
+
+// encodeUpdateItemInput returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export declare function encodeUpdateItemInput(v: UpdateItemInput): any
+
+// UpdateItemOutput params.
+export interface UpdateItemOutput {
+  // item is the item updated.
+  item?: Item
+}
+
+// decodeUpdateItemOutput returns v decoded from JSON, with dates in place of its timestamps.
+export declare function decodeUpdateItemOutput(v: any): UpdateItemOutput
+
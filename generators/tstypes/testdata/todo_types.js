@@ -0,0 +1,169 @@
+// ValidationError is an error of params failing validation.
+export class ValidationError extends Error {}
+
+// validateItem throws a ValidationError when v is invalid.
+export function validateItem(v) {
+  if (Array.from(v.text).length > 200) {
+    throw new ValidationError("text must be at most 200 characters long")
+  }
+  if (v.url != null && !new RegExp("^[a-zA-Z][a-zA-Z0-9+.-]*:\\S+$").test(v.url)) {
+    throw new ValidationError("url must be a valid URI")
+  }
+}
+
+// decodeItem returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeItem(v) {
+  const o = { ...v }
+  if (o.created_at != null) {
+    o.created_at = new Date(o.created_at)
+  }
+  if (o.reminder != null) {
+    o.reminder = decodeReminder(o.reminder)
+  }
+  return o
+}
+
+// encodeItem returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeItem(v) {
+  const o = { ...v }
+  if (o.created_at != null) {
+    o.created_at = new Date(o.created_at).toISOString()
+  }
+  if (o.reminder != null) {
+    o.reminder = encodeReminder(o.reminder)
+  }
+  return o
+}
+
+// validateItemInput throws a ValidationError when v is invalid.
+export function validateItemInput(v) {
+  if (Array.from(v.text).length > 200) {
+    throw new ValidationError("text must be at most 200 characters long")
+  }
+  if (v.url != null && !new RegExp("^[a-zA-Z][a-zA-Z0-9+.-]*:\\S+$").test(v.url)) {
+    throw new ValidationError("url must be a valid URI")
+  }
+}
+
+// decodeItemInput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeItemInput(v) {
+  const o = { ...v }
+  if (o.reminder != null) {
+    o.reminder = decodeReminder(o.reminder)
+  }
+  return o
+}
+
+// encodeItemInput returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeItemInput(v) {
+  const o = { ...v }
+  if (o.reminder != null) {
+    o.reminder = encodeReminder(o.reminder)
+  }
+  return o
+}
+
+// decodeReminder returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeReminder(v) {
+  switch (v.type) {
+    case 'time_reminder':
+      return decodeTimeReminder(v)
+    default:
+      return v
+  }
+}
+
+// encodeReminder returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeReminder(v) {
+  switch (v.type) {
+    case 'time_reminder':
+      return encodeTimeReminder(v)
+    default:
+      return v
+  }
+}
+
+// decodeTimeReminder returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeTimeReminder(v) {
+  const o = { ...v }
+  if (o.at != null) {
+    o.at = new Date(o.at)
+  }
+  return o
+}
+
+// encodeTimeReminder returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeTimeReminder(v) {
+  const o = { ...v }
+  if (o.at != null) {
+    o.at = new Date(o.at).toISOString()
+  }
+  return o
+}
+
+// validateAddItemInput throws a ValidationError when v is invalid.
+export function validateAddItemInput(v) {
+  if (Array.from(v.item).length < 1) {
+    throw new ValidationError("item must be at least 1 character long")
+  }
+  if (Array.from(v.item).length > 200) {
+    throw new ValidationError("item must be at most 200 characters long")
+  }
+}
+
+// decodeGetItemsOutput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeGetItemsOutput(v) {
+  const o = { ...v }
+  if (o.items != null) {
+    o.items = o.items.map(v => decodeItem(v))
+  }
+  if (o.lists != null) {
+    o.lists = Object.fromEntries(Object.entries(o.lists).map(([k, v]) => [k, v.map(v => decodeItem(v))]))
+  }
+  return o
+}
+
+// validateRemoveItemInput throws a ValidationError when v is invalid.
+export function validateRemoveItemInput(v) {
+  if (v.id != null && v.id < 1) {
+    throw new ValidationError("id must be at least 1")
+  }
+}
+
+// decodeRemoveItemOutput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeRemoveItemOutput(v) {
+  const o = { ...v }
+  if (o.item != null) {
+    o.item = decodeItem(o.item)
+  }
+  return o
+}
+
+// validateUpdateItemInput throws a ValidationError when v is invalid.
+export function validateUpdateItemInput(v) {
+  if (v.id < 1) {
+    throw new ValidationError("id must be at least 1")
+  }
+  if (v.item != null) {
+    validateItemInput(v.item)
+  }
+}
+
+// encodeUpdateItemInput returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeUpdateItemInput(v) {
+  const o = { ...v }
+  if (o.item != null) {
+    o.item = encodeItemInput(o.item)
+  }
+  return o
+}
+
+// decodeUpdateItemOutput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeUpdateItemOutput(v) {
+  const o = { ...v }
+  if (o.item != null) {
+    o.item = decodeItem(o.item)
+  }
+  return o
+}
+
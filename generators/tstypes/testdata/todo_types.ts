@@ -1,36 +1,314 @@
+// ValidationError is an error of params failing validation.
+export class ValidationError extends Error {}
+
+// ItemStatus is the status of the to-do item.
+export type ItemStatus = 'pending' | 'completed'
+
+// Priority is the priority of a to-do item.
+export type Priority = 'low' | 'normal' | 'high'
+
 // Item is a to-do item.
 export interface Item {
-  // created_at is the time the to-do item was created.
+  // created_at is the time the to-do item was created. This field is read-only.
   created_at?: Date
 
+  // done is whether the to-do item is done.
+  /** @deprecated Use status instead. */
+  done?: boolean
+
   // id is the id of the item. This field is read-only.
   id?: number
 
-  // text is the to-do item text. This field is required.
+  // labels is the labels of the to-do item, keyed by name.
+  labels?: Record<string, string>
+
+  // priority is the priority of the to-do item. Defaults to "normal".
+  /** @default "normal" */
+  priority?: Priority
+
+  // reminder is the reminder of the to-do item.
+  reminder?: Reminder
+
+  // status is the status of the to-do item. Must be one of: "pending", "completed".
+  status?: ItemStatus
+
+  // text is the to-do item text. This field is required. Must be at most 200 characters long.
+  text: string
+
+  // url is the link of the to-do item. Must be a valid URI.
+  url?: string
+}
+
+// validateItem throws a ValidationError when v is invalid.
+export function validateItem(v: Item) {
+  if (Array.from(v.text).length > 200) {
+    throw new ValidationError("text must be at most 200 characters long")
+  }
+  if (v.url != null && !new RegExp("^[a-zA-Z][a-zA-Z0-9+.-]*:\\S+$").test(v.url)) {
+    throw new ValidationError("url must be a valid URI")
+  }
+}
+
+// decodeItem returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeItem(v: any): Item {
+  const o: any = { ...v }
+  if (o.created_at != null) {
+    o.created_at = new Date(o.created_at)
+  }
+  if (o.reminder != null) {
+    o.reminder = decodeReminder(o.reminder)
+  }
+  return o
+}
+
+// encodeItem returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeItem(v: Item): any {
+  const o: any = { ...v }
+  if (o.created_at != null) {
+    o.created_at = new Date(o.created_at).toISOString()
+  }
+  if (o.reminder != null) {
+    o.reminder = encodeReminder(o.reminder)
+  }
+  return o
+}
+
+// ItemInput is a to-do item. Read-only fields are omitted.
+export interface ItemInput {
+  // done is whether the to-do item is done.
+  /** @deprecated Use status instead. */
+  done?: boolean
+
+  // labels is the labels of the to-do item, keyed by name.
+  labels?: Record<string, string>
+
+  // priority is the priority of the to-do item. Defaults to "normal".
+  /** @default "normal" */
+  priority?: Priority
+
+  // reminder is the reminder of the to-do item.
+  reminder?: Reminder
+
+  // status is the status of the to-do item. Must be one of: "pending", "completed".
+  status?: ItemStatus
+
+  // text is the to-do item text. This field is required. Must be at most 200 characters long.
   text: string
+
+  // url is the link of the to-do item. Must be a valid URI.
+  url?: string
+}
+
+// validateItemInput throws a ValidationError when v is invalid.
+export function validateItemInput(v: ItemInput) {
+  if (Array.from(v.text).length > 200) {
+    throw new ValidationError("text must be at most 200 characters long")
+  }
+  if (v.url != null && !new RegExp("^[a-zA-Z][a-zA-Z0-9+.-]*:\\S+$").test(v.url)) {
+    throw new ValidationError("url must be a valid URI")
+  }
+}
+
+// decodeItemInput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeItemInput(v: any): ItemInput {
+  const o: any = { ...v }
+  if (o.reminder != null) {
+    o.reminder = decodeReminder(o.reminder)
+  }
+  return o
+}
+
+// encodeItemInput returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeItemInput(v: ItemInput): any {
+  const o: any = { ...v }
+  if (o.reminder != null) {
+    o.reminder = encodeReminder(o.reminder)
+  }
+  return o
+}
+
+// LocationReminder is a reminder when arriving at a location.
+export interface LocationReminder {
+  // latitude is the latitude of the location. This field is required.
+  latitude: number
+
+  // longitude is the longitude of the location. This field is required.
+  longitude: number
+}
+
+// Reminder is a reminder for a to-do item.
+export type Reminder = ({ type: 'time_reminder' } & TimeReminder) | ({ type: 'location_reminder' } & LocationReminder)
+
+// decodeReminder returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeReminder(v: any): Reminder {
+  switch (v.type) {
+    case 'time_reminder':
+      return decodeTimeReminder(v) as Reminder
+    default:
+      return v
+  }
+}
+
+// encodeReminder returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeReminder(v: Reminder): any {
+  switch (v.type) {
+    case 'time_reminder':
+      return encodeTimeReminder(v)
+    default:
+      return v
+  }
+}
+
+// Stats is the statistics of a to-do list.
+export interface Stats {
+  // completed is the number of completed items.
+  completed?: number
+
+  // total is the number of items.
+  total?: number
+}
+
+// TimeReminder is a reminder at a point in time.
+export interface TimeReminder {
+  // at is the time to remind at. This field is required.
+  at: Date
+}
+
+// decodeTimeReminder returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeTimeReminder(v: any): TimeReminder {
+  const o: any = { ...v }
+  if (o.at != null) {
+    o.at = new Date(o.at)
+  }
+  return o
+}
+
+// encodeTimeReminder returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeTimeReminder(v: TimeReminder): any {
+  const o: any = { ...v }
+  if (o.at != null) {
+    o.at = new Date(o.at).toISOString()
+  }
+  return o
 }
 
 // AddItemInput params.
-interface AddItemInput {
-  // item is the item to add. This field is required.
+export interface AddItemInput {
+  // item is the item to add. This field is required. Must be at least 1 character long. Must be at most 200 characters long.
   item: string
+
+  // priority is the priority of the item. Defaults to "normal".
+  /** @default "normal" */
+  priority?: Priority
+}
+
+// validateAddItemInput throws a ValidationError when v is invalid.
+export function validateAddItemInput(v: AddItemInput) {
+  if (Array.from(v.item).length < 1) {
+    throw new ValidationError("item must be at least 1 character long")
+  }
+  if (Array.from(v.item).length > 200) {
+    throw new ValidationError("item must be at most 200 characters long")
+  }
 }
 
 // GetItemsOutput params.
-interface GetItemsOutput {
+export interface GetItemsOutput {
   // items is the list of to-do items.
   items?: Item[]
+
+  // lists is the to-do items grouped by list name.
+  lists?: Record<string, Item[]>
+}
+
+// decodeGetItemsOutput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeGetItemsOutput(v: any): GetItemsOutput {
+  const o: any = { ...v }
+  if (o.items != null) {
+    o.items = o.items.map((v: any) => decodeItem(v))
+  }
+  if (o.lists != null) {
+    o.lists = Object.fromEntries(Object.entries(o.lists).map(([k, v]) => [k, v.map((v: any) => decodeItem(v))]))
+  }
+  return o
+}
+
+// GetStatsOutput params.
+export interface GetStatsOutput {
+  // stats is the statistics.
+  stats?: Stats
 }
 
 // RemoveItemInput params.
-interface RemoveItemInput {
-  // id is the id of the item to remove.
+/** @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01. */
+export interface RemoveItemInput {
+  // id is the id of the item to remove. Must be at least 1.
   id?: number
 }
 
+// validateRemoveItemInput throws a ValidationError when v is invalid.
+export function validateRemoveItemInput(v: RemoveItemInput) {
+  if (v.id != null && v.id < 1) {
+    throw new ValidationError("id must be at least 1")
+  }
+}
+
 // RemoveItemOutput params.
-interface RemoveItemOutput {
+/** @deprecated Set the item status to completed instead. Removal is scheduled for 2027-06-01. */
+export interface RemoveItemOutput {
   // item is the item removed.
   item?: Item
 }
 
+// decodeRemoveItemOutput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeRemoveItemOutput(v: any): RemoveItemOutput {
+  const o: any = { ...v }
+  if (o.item != null) {
+    o.item = decodeItem(o.item)
+  }
+  return o
+}
+
+// UpdateItemInput params.
+export interface UpdateItemInput {
+  // id is the id of the item to update. This field is required. Must be at least 1.
+  id: number
+
+  // item is the updated item. This field is required.
+  item: ItemInput
+}
+
+// validateUpdateItemInput throws a ValidationError when v is invalid.
+export function validateUpdateItemInput(v: UpdateItemInput) {
+  if (v.id < 1) {
+    throw new ValidationError("id must be at least 1")
+  }
+  if (v.item != null) {
+    validateItemInput(v.item)
+  }
+}
+
+// encodeUpdateItemInput returns v encoded for JSON, with ISO 8601 strings in place of its dates.
+export function encodeUpdateItemInput(v: UpdateItemInput): any {
+  const o: any = { ...v }
+  if (o.item != null) {
+    o.item = encodeItemInput(o.item)
+  }
+  return o
+}
+
+// UpdateItemOutput params.
+export interface UpdateItemOutput {
+  // item is the item updated.
+  item?: Item
+}
+
+// decodeUpdateItemOutput returns v decoded from JSON, with dates in place of its timestamps.
+export function decodeUpdateItemOutput(v: any): UpdateItemOutput {
+  const o: any = { ...v }
+  if (o.item != null) {
+    o.item = decodeItem(o.item)
+  }
+  return o
+}
+
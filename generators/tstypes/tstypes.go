@@ -1,24 +1,123 @@
 package tstypes
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/newlix/rpc/internal/format"
 	"github.com/newlix/rpc/internal/schemautil"
 	"github.com/newlix/rpc/schema"
 )
 
-// Generate writes the TS type implementations to w.
-func Generate(w io.Writer, s *schema.Schema) error {
+// Mode of the generated code.
+type Mode string
+
+// Modes available.
+const (
+	// TS generates TypeScript.
+	TS Mode = "ts"
+
+	// JS generates JavaScript, without the types.
+	JS Mode = "js"
+
+	// Declarations generates the TypeScript declarations of the JavaScript.
+	Declarations Mode = "d.ts"
+)
+
+// Validate returns an error if the mode is not supported.
+func (m Mode) Validate() error {
+	switch m {
+	case TS, JS, Declarations:
+		return nil
+	default:
+		return fmt.Errorf("unsupported mode %q, must be one of: ts, js, d.ts", m)
+	}
+}
+
+// Generate writes the TS type implementations to w, as an ES module of the
+// given mode.
+func Generate(w io.Writer, s *schema.Schema, mode Mode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+
+	s = schemautil.Inputs(s)
 	out := fmt.Fprintf
 
+	// mapped types
+	if mode != JS {
+		writeImports(w, s)
+	}
+
+	// validation
+	if validates(s) {
+		out(w, "// ValidationError is an error of params failing validation.\n")
+		if mode == Declarations {
+			out(w, "export declare class ValidationError extends Error {}\n\n")
+		} else {
+			out(w, "export class ValidationError extends Error {}\n\n")
+		}
+	}
+
+	// JavaScript has only the functions
+	if mode == JS {
+		for _, t := range s.TypesSlice() {
+			if validatesType(s, t) {
+				writeValidate(w, s, mode, format.GoName(t.Name), t.Properties)
+				out(w, "\n")
+			}
+			writeTypeCodecs(w, s, mode, t)
+		}
+
+		for _, m := range s.Methods {
+			name := format.GoName(m.Name)
+			if schemautil.Validates(s, m.Inputs) {
+				writeValidate(w, s, mode, name+"Input", m.Inputs)
+				out(w, "\n")
+			}
+			if schemautil.HasTimestamps(s, schemautil.TS, m.Inputs) {
+				writeCodec(w, s, mode, name+"Input", m.Inputs, false)
+				out(w, "\n")
+			}
+			if schemautil.HasTimestamps(s, schemautil.TS, m.Outputs) {
+				writeCodec(w, s, mode, name+"Output", m.Outputs, true)
+				out(w, "\n")
+			}
+		}
+
+		return nil
+	}
+
+	// enums
+	for _, t := range schemautil.Enums(s) {
+		if _, ok := schemautil.TypeMapping(schemautil.TS, t); ok {
+			continue
+		}
+		writeEnum(w, t)
+	}
+
 	// types
 	for _, t := range s.TypesSlice() {
+		if _, ok := schemautil.TypeMapping(schemautil.TS, t); ok || t.IsEnum() {
+			continue
+		}
+		if t.IsUnion() {
+			writeUnion(w, s, t)
+			writeTypeCodecs(w, s, mode, t)
+			continue
+		}
 		out(w, "// %s %s\n", format.GoName(t.Name), t.Description)
+		writeDeprecated(w, "", t.Deprecated)
 		out(w, "export interface %s {\n", format.GoName(t.Name))
-		writeFields(w, s, t.Properties)
+		writeFields(w, s, schemautil.Owner(t), t.Properties)
 		out(w, "}\n\n")
+		if validatesType(s, t) {
+			writeValidate(w, s, mode, format.GoName(t.Name), t.Properties)
+			out(w, "\n")
+		}
+		writeTypeCodecs(w, s, mode, t)
 	}
 
 	// method types
@@ -28,9 +127,18 @@ func Generate(w io.Writer, s *schema.Schema) error {
 		// inputs
 		if len(m.Inputs) > 0 {
 			out(w, "// %sInput params.\n", name)
-			out(w, "interface %sInput {\n", name)
-			writeFields(w, s, m.Inputs)
+			writeDeprecated(w, "", m.Deprecated)
+			out(w, "export interface %sInput {\n", name)
+			writeFields(w, s, m.Name+"_input", m.Inputs)
 			out(w, "}\n")
+			if schemautil.Validates(s, m.Inputs) {
+				out(w, "\n")
+				writeValidate(w, s, mode, name+"Input", m.Inputs)
+			}
+			if schemautil.HasTimestamps(s, schemautil.TS, m.Inputs) {
+				out(w, "\n")
+				writeCodec(w, s, mode, name+"Input", m.Inputs, false)
+			}
 		}
 
 		// both
@@ -41,9 +149,14 @@ func Generate(w io.Writer, s *schema.Schema) error {
 		// outputs
 		if len(m.Outputs) > 0 {
 			out(w, "// %sOutput params.\n", name)
-			out(w, "interface %sOutput {\n", name)
-			writeFields(w, s, m.Outputs)
+			writeDeprecated(w, "", m.Deprecated)
+			out(w, "export interface %sOutput {\n", name)
+			writeFields(w, s, m.Name+"_output", m.Outputs)
 			out(w, "}\n")
+			if schemautil.HasTimestamps(s, schemautil.TS, m.Outputs) {
+				out(w, "\n")
+				writeCodec(w, s, mode, name+"Output", m.Outputs, true)
+			}
 		}
 
 		out(w, "\n")
@@ -52,10 +165,326 @@ func Generate(w io.Writer, s *schema.Schema) error {
 	return nil
 }
 
+// writeImports writes the imports of the mapped types used by s to w, as
+// named imports of the modules.
+func writeImports(w io.Writer, s *schema.Schema) {
+	var modules []string
+	names := map[string][]string{}
+	seen := map[string]bool{}
+	for _, m := range schemautil.Mappings(s, schemautil.TS) {
+		if names[m.Import] == nil {
+			modules = append(modules, m.Import)
+		}
+		if name := importName(m.Type); !seen[m.Import+" "+name] {
+			seen[m.Import+" "+name] = true
+			names[m.Import] = append(names[m.Import], name)
+		}
+	}
+
+	for _, module := range modules {
+		fmt.Fprintf(w, "import type { %s } from '%s'\n", strings.Join(names[module], ", "), module)
+	}
+
+	if len(modules) > 0 {
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// importName returns the name imported for the mapped type t, such as
+// "Decimal" for "Decimal" or "Big" for "Big.Big".
+func importName(t string) string {
+	if i := strings.IndexAny(t, ".<["); i != -1 {
+		return t[:i]
+	}
+	return t
+}
+
+// writeEnum writes the enum type t as a string literal union to w. Values
+// unknown to this version of the client are passed through as-is.
+func writeEnum(w io.Writer, t schema.Type) {
+	var values []string
+	for _, v := range t.Enum {
+		values = append(values, "'"+v+"'")
+	}
+
+	fmt.Fprintf(w, "// %s %s\n", format.GoName(t.Name), t.Description)
+	writeDeprecated(w, "", t.Deprecated)
+	fmt.Fprintf(w, "export type %s = %s\n\n", format.GoName(t.Name), strings.Join(values, " | "))
+}
+
+// writeUnion writes the union type t to w, discriminated by the variant type name.
+func writeUnion(w io.Writer, s *schema.Schema, t schema.Type) {
+	var variants []string
+	for _, v := range schemautil.Variants(s, t) {
+		variants = append(variants, fmt.Sprintf("({ %s: '%s' } & %s)", t.Discriminator, v.Name, format.GoName(v.Name)))
+	}
+
+	fmt.Fprintf(w, "// %s %s\n", format.GoName(t.Name), t.Description)
+	writeDeprecated(w, "", t.Deprecated)
+	fmt.Fprintf(w, "export type %s = %s\n\n", format.GoName(t.Name), strings.Join(variants, " | "))
+}
+
+// validatesType returns true if the interface of type t has a validation function.
+func validatesType(s *schema.Schema, t schema.Type) bool {
+	if _, ok := schemautil.TypeMapping(schemautil.TS, t); ok || t.IsEnum() || t.IsUnion() {
+		return false
+	}
+	return schemautil.Validates(s, t.Properties)
+}
+
+// validates returns true if any of the types or method inputs have validation checks.
+func validates(s *schema.Schema) bool {
+	for _, t := range s.Types {
+		if schemautil.Validates(s, t.Properties) {
+			return true
+		}
+	}
+
+	for _, m := range s.Methods {
+		if schemautil.Validates(s, m.Inputs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeValidate writes the validation function of the interface name to w,
+// checking the constraints of its fields and the interfaces they reference.
+func writeValidate(w io.Writer, s *schema.Schema, mode Mode, name string, fields []schema.Field) {
+	out := fmt.Fprintf
+	out(w, "// validate%s throws a ValidationError when v is invalid.\n", name)
+	switch mode {
+	case Declarations:
+		out(w, "export declare function validate%s(v: %s): void\n", name, name)
+		return
+	case JS:
+		out(w, "export function validate%s(v) {\n", name)
+	default:
+		out(w, "export function validate%s(v: %s) {\n", name, name)
+	}
+	for _, f := range fields {
+		if schemautil.IsMapped(s, schemautil.TS, f) {
+			continue
+		}
+		field := "v." + f.Name
+		for _, c := range schemautil.Checks(f) {
+			var cond string
+			switch c.Kind {
+			case schemautil.MinLength:
+				cond = fmt.Sprintf("Array.from(%s).length < %d", field, c.Length)
+			case schemautil.MaxLength:
+				cond = fmt.Sprintf("Array.from(%s).length > %d", field, c.Length)
+			case schemautil.Pattern, schemautil.Format:
+				pattern, _ := json.Marshal(c.Pattern)
+				cond = fmt.Sprintf("!new RegExp(%s).test(%s)", pattern, field)
+			case schemautil.Minimum:
+				cond = fmt.Sprintf("%s < %s", field, c.Number)
+			case schemautil.Maximum:
+				cond = fmt.Sprintf("%s > %s", field, c.Number)
+			}
+
+			// optional fields are only checked when present
+			if !f.Required {
+				cond = fmt.Sprintf("%s != null && %s", field, cond)
+			}
+
+			message, _ := json.Marshal(f.Name + " " + c.Message)
+			out(w, "  if (%s) {\n", cond)
+			out(w, "    throw new ValidationError(%s)\n", message)
+			out(w, "  }\n")
+		}
+
+		t, ok := schemautil.ValidatedRef(s, f)
+		if !ok || !schemautil.Validates(s, t.Properties) {
+			continue
+		}
+
+		if f.Type.Type == schema.Array {
+			out(w, "  %s?.forEach(validate%s)\n", field, format.GoName(t.Name))
+		} else {
+			out(w, "  if (%s != null) {\n", field)
+			out(w, "    validate%s(%s)\n", format.GoName(t.Name), field)
+			out(w, "  }\n")
+		}
+	}
+	out(w, "}\n")
+}
+
+// writeTypeCodecs writes the functions decoding and encoding the timestamps
+// of type t to w, if any.
+func writeTypeCodecs(w io.Writer, s *schema.Schema, mode Mode, t schema.Type) {
+	if t.IsEnum() || !schemautil.TypeHasTimestamps(s, schemautil.TS, t) {
+		return
+	}
+
+	for _, decode := range []bool{true, false} {
+		if t.IsUnion() {
+			writeUnionCodec(w, s, mode, t, decode)
+		} else {
+			writeCodec(w, s, mode, format.GoName(t.Name), t.Properties, decode)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// writeCodecDoc writes the comment and signature of the function decoding
+// or encoding the timestamps of the interface name to w.
+func writeCodecDoc(w io.Writer, mode Mode, name string, decode bool) {
+	out := fmt.Fprintf
+	if decode {
+		out(w, "// decode%s returns v decoded from JSON, with dates in place of its timestamps.\n", name)
+	} else {
+		out(w, "// encode%s returns v encoded for JSON, with ISO 8601 strings in place of its dates.\n", name)
+	}
+
+	switch {
+	case mode == Declarations && decode:
+		out(w, "export declare function decode%s(v: any): %s\n", name, name)
+	case mode == Declarations:
+		out(w, "export declare function encode%s(v: %s): any\n", name, name)
+	case mode == JS && decode:
+		out(w, "export function decode%s(v) {\n", name)
+	case mode == JS:
+		out(w, "export function encode%s(v) {\n", name)
+	case decode:
+		out(w, "export function decode%s(v: any): %s {\n", name, name)
+	default:
+		out(w, "export function encode%s(v: %s): any {\n", name, name)
+	}
+}
+
+// writeCodec writes the function decoding or encoding the timestamps of the
+// interface name to w, converting the fields which contain timestamps of a
+// copy of v.
+func writeCodec(w io.Writer, s *schema.Schema, mode Mode, name string, fields []schema.Field, decode bool) {
+	out := fmt.Fprintf
+	writeCodecDoc(w, mode, name, decode)
+	if mode == Declarations {
+		return
+	}
+
+	if mode == JS {
+		out(w, "  const o = { ...v }\n")
+	} else {
+		out(w, "  const o: any = { ...v }\n")
+	}
+	for _, f := range fields {
+		if !schemautil.HasTimestamps(s, schemautil.TS, []schema.Field{f}) {
+			continue
+		}
+		field := "o." + f.Name
+		out(w, "  if (%s != null) {\n", field)
+		out(w, "    %s = %s\n", field, convert(s, mode, field, f, decode))
+		out(w, "  }\n")
+	}
+	out(w, "  return o\n")
+	out(w, "}\n")
+}
+
+// writeUnionCodec writes the function decoding or encoding the timestamps of
+// union t to w, by the function of the variant named by the discriminator.
+func writeUnionCodec(w io.Writer, s *schema.Schema, mode Mode, t schema.Type, decode bool) {
+	out := fmt.Fprintf
+	name := format.GoName(t.Name)
+	writeCodecDoc(w, mode, name, decode)
+	if mode == Declarations {
+		return
+	}
+
+	prefix := "encode"
+	if decode {
+		prefix = "decode"
+	}
+
+	out(w, "  switch (v.%s) {\n", t.Discriminator)
+	for _, v := range schemautil.Variants(s, t) {
+		if !schemautil.TypeHasTimestamps(s, schemautil.TS, v) {
+			continue
+		}
+		out(w, "    case '%s':\n", v.Name)
+		if mode == TS && decode {
+			out(w, "      return %s%s(v) as %s\n", prefix, format.GoName(v.Name), name)
+		} else {
+			out(w, "      return %s%s(v)\n", prefix, format.GoName(v.Name))
+		}
+	}
+	out(w, "    default:\n")
+	out(w, "      return v\n")
+	out(w, "  }\n")
+	out(w, "}\n")
+}
+
+// convert returns the expression decoding or encoding the timestamps of the
+// value x of field f, which contains timestamps.
+func convert(s *schema.Schema, mode Mode, x string, f schema.Field, decode bool) string {
+	if ref := f.Type.Ref; ref.Value != "" {
+		t := schemautil.ResolveRef(s, ref)
+		if decode {
+			return fmt.Sprintf("decode%s(%s)", format.GoName(t.Name), x)
+		}
+		return fmt.Sprintf("encode%s(%s)", format.GoName(t.Name), x)
+	}
+
+	param := "(v: any)"
+	if mode == JS {
+		param = "v"
+	}
+
+	switch f.Type.Type {
+	case schema.Timestamp:
+		if decode {
+			return fmt.Sprintf("new Date(%s)", x)
+		}
+		return fmt.Sprintf("new Date(%s).toISOString()", x)
+	case schema.Array:
+		return fmt.Sprintf("%s.map(%s => %s)", x, param, convert(s, mode, "v", f.Items.Field(), decode))
+	default:
+		return fmt.Sprintf("Object.fromEntries(Object.entries(%s).map(([k, v]) => [k, %s]))", x, convert(s, mode, "v", f.Values.Field(), decode))
+	}
+}
+
+// writeDeprecated writes the deprecation notice of d to w, if any, as JSDoc.
+func writeDeprecated(w io.Writer, indent string, d *schema.Deprecation) {
+	if d == nil {
+		return
+	}
+
+	fmt.Fprintf(w, "%s/** @deprecated %s */\n", indent, d.Notice())
+}
+
+// writeFieldTags writes the default value and deprecation notice of field f
+// to w, if any, as JSDoc. Defaults are documented only, the decoders leave
+// absent fields undefined.
+func writeFieldTags(w io.Writer, f schema.Field) {
+	var tags []string
+
+	if f.Default != nil {
+		b, _ := json.Marshal(f.Default)
+		tags = append(tags, "@default "+string(b))
+	}
+
+	if f.Deprecated != nil {
+		tags = append(tags, "@deprecated "+f.Deprecated.Notice())
+	}
+
+	switch len(tags) {
+	case 0:
+	case 1:
+		fmt.Fprintf(w, "  /** %s */\n", tags[0])
+	default:
+		fmt.Fprintf(w, "  /**\n")
+		for _, t := range tags {
+			fmt.Fprintf(w, "   * %s\n", t)
+		}
+		fmt.Fprintf(w, "   */\n")
+	}
+}
+
 // writeFields to writer.
-func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
+func writeFields(w io.Writer, s *schema.Schema, owner string, fields []schema.Field) {
 	for i, f := range fields {
-		writeField(w, s, f)
+		writeField(w, s, owner, f)
 		if i < len(fields)-1 {
 			fmt.Fprintf(w, "\n")
 		}
@@ -63,17 +492,33 @@ func writeFields(w io.Writer, s *schema.Schema, fields []schema.Field) {
 }
 
 // writeField to writer.
-func writeField(w io.Writer, s *schema.Schema, f schema.Field) {
+func writeField(w io.Writer, s *schema.Schema, owner string, f schema.Field) {
+	t := jsType(s, f)
+	if _, ok := schemautil.Mapping(s, schemautil.TS, f); !ok && schemautil.IsInlineEnum(f) {
+		t = format.GoName(schemautil.EnumName(owner, f))
+	}
+
 	fmt.Fprintf(w, "  // %s is %s%s\n", f.Name, f.Description, schemautil.FormatExtra(f))
+	writeFieldTags(w, f)
 	if f.Required {
-		fmt.Fprintf(w, "  %s: %s\n", f.Name, jsType(s, f))
+		fmt.Fprintf(w, "  %s: %s\n", f.Name, t)
 	} else {
-		fmt.Fprintf(w, "  %s?: %s\n", f.Name, jsType(s, f))
+		fmt.Fprintf(w, "  %s?: %s\n", f.Name, t)
 	}
 }
 
+// Type returns the TypeScript type of field f, as used by the generated types.
+func Type(s *schema.Schema, f schema.Field) string {
+	return jsType(s, f)
+}
+
 // jsType returns a JS equivalent type for field f.
 func jsType(s *schema.Schema, f schema.Field) string {
+	// mapping
+	if m, ok := schemautil.Mapping(s, schemautil.TS, f); ok {
+		return m.Type
+	}
+
 	// ref
 	if ref := f.Type.Ref.Value; ref != "" {
 		t := schemautil.ResolveRef(s, f.Type.Ref)
@@ -91,11 +536,12 @@ func jsType(s *schema.Schema, f schema.Field) string {
 	case schema.Timestamp:
 		return "Date"
 	case schema.Object:
-		return "object"
+		if !f.HasValues() {
+			return "object"
+		}
+		return "Record<string, " + jsType(s, f.Values.Field()) + ">"
 	case schema.Array:
-		return jsType(s, schema.Field{
-			Type: schema.TypeObject(f.Items),
-		}) + "[]"
+		return jsType(s, f.Items.Field()) + "[]"
 	default:
 		panic("unhandled type")
 	}
@@ -16,8 +16,41 @@ func TestGenerate(t *testing.T) {
 	assert.NoError(t, err, "loading schema")
 
 	var act bytes.Buffer
-	err = tstypes.Generate(&act, schema)
+	err = tstypes.Generate(&act, schema, tstypes.TS)
 	assert.NoError(t, err, "generating")
 
 	fixture.Assert(t, "todo_types.ts", act.Bytes())
 }
+
+func TestGenerate_mappings(t *testing.T) {
+	schema, err := schema.Load("../../schema/testdata/mapping.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tstypes.Generate(&act, schema, tstypes.TS)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "mapping_types.ts", act.Bytes())
+}
+
+func TestGenerate_js(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tstypes.Generate(&act, schema, tstypes.JS)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_types.js", act.Bytes())
+}
+
+func TestGenerate_declarations(t *testing.T) {
+	schema, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	var act bytes.Buffer
+	err = tstypes.Generate(&act, schema, tstypes.Declarations)
+	assert.NoError(t, err, "generating")
+
+	fixture.Assert(t, "todo_types.d.ts", act.Bytes())
+}
@@ -1,6 +1,6 @@
 module github.com/newlix/rpc
 
-go 1.21
+go 1.23.0
 
 require (
 	github.com/iancoleman/strcase v0.0.0-20191112232945-16388991a334
@@ -8,6 +8,8 @@ require (
 	github.com/tj/assert v0.0.0-20190920132354-ee03d75cd160
 	github.com/tj/go-fixture v1.0.0
 	github.com/xeipuuv/gojsonschema v1.2.0
+	golang.org/x/tools v0.34.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -26,8 +28,9 @@ require (
 	github.com/stretchr/testify v1.4.0 // indirect
 	github.com/xeipuuv/gojsonpointer v0.0.0-20180127040702-4e3ac2762d5f // indirect
 	github.com/xeipuuv/gojsonreference v0.0.0-20180127040603-bd5ef7bd5415 // indirect
-	golang.org/x/sys v0.15.0 // indirect
+	golang.org/x/mod v0.25.0 // indirect
+	golang.org/x/sync v0.15.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/xerrors v0.0.0-20231012003039-104605ab7028 // indirect
 	gopkg.in/yaml.v2 v2.2.4 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
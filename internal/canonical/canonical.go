@@ -0,0 +1,252 @@
+// Package canonical writes schema documents with their properties in a canonical order.
+package canonical
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// order is the canonical order of schema properties, any others
+// follow in alphabetical order.
+var order = []string{
+	"$ref",
+	"name",
+	"version",
+	"description",
+	"summary",
+	"include",
+	"group",
+	"private",
+	"deprecated",
+	"message",
+	"sunset",
+	"type",
+	"discriminator",
+	"oneOf",
+	"items",
+	"values",
+	"enum",
+	"minLength",
+	"maxLength",
+	"pattern",
+	"format",
+	"minimum",
+	"maximum",
+	"required",
+	"readonly",
+	"default",
+	"inputs",
+	"outputs",
+	"properties",
+	"input",
+	"output",
+	"value",
+	"examples",
+	"groups",
+	"methods",
+	"types",
+	"go",
+	"ts",
+	"swift",
+	"kotlin",
+}
+
+// mode of a value within the document.
+type mode int
+
+// Modes available.
+const (
+	// schema values have properties in canonical order.
+	schema mode = iota
+
+	// names values have schema values keyed by name, in alphabetical order.
+	names
+
+	// data values are user data such as examples, in alphabetical order.
+	data
+)
+
+// Write the JSON document b to w in format "json" or "yaml".
+func Write(w io.Writer, b []byte, format string) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("decoding: %w", err)
+	}
+
+	v := sorted(doc, schema)
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// sorted returns v with its objects in the order of mode m.
+func sorted(v interface{}, m mode) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		var o object
+		for _, k := range keys(v, m) {
+			o = append(o, member{
+				key:   k,
+				value: sorted(v[k], child(k, m)),
+			})
+		}
+		return o
+	case []interface{}:
+		for i := range v {
+			v[i] = sorted(v[i], m)
+		}
+		return v
+	case json.Number:
+		return number(v)
+	default:
+		return v
+	}
+}
+
+// child returns the mode of the value of property k in mode m.
+func child(k string, m mode) mode {
+	switch {
+	case m == data:
+		return data
+	case m == names:
+		return schema
+	case k == "types":
+		return names
+	case k == "default" || k == "input" || k == "output" || k == "value":
+		return data
+	default:
+		return schema
+	}
+}
+
+// keys returns the keys of object v in the order of mode m.
+func keys(v map[string]interface{}, m mode) []string {
+	var list []string
+	for k := range v {
+		list = append(list, k)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if m == schema {
+			a, b := rank(list[i]), rank(list[j])
+			if a != b {
+				return a < b
+			}
+		}
+		return list[i] < list[j]
+	})
+
+	return list
+}
+
+// rank returns the canonical position of property k.
+func rank(k string) int {
+	for i, v := range order {
+		if v == k {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// member of an object.
+type member struct {
+	key   string
+	value interface{}
+}
+
+// object is an object with ordered members.
+type object []member
+
+// MarshalJSON implementation.
+func (o object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := enc.Encode(m.key); err != nil {
+			return nil, err
+		}
+		buf.WriteByte(':')
+
+		if err := enc.Encode(m.value); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implementation.
+func (o object) MarshalYAML() (interface{}, error) {
+	n := &yaml.Node{
+		Kind: yaml.MappingNode,
+	}
+
+	for _, m := range o {
+		var k, v yaml.Node
+
+		if err := k.Encode(m.key); err != nil {
+			return nil, err
+		}
+
+		if err := v.Encode(m.value); err != nil {
+			return nil, err
+		}
+
+		n.Content = append(n.Content, &k, &v)
+	}
+
+	return n, nil
+}
+
+// number is a JSON number, written as-is.
+type number json.Number
+
+// MarshalJSON implementation.
+func (n number) MarshalJSON() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// MarshalYAML implementation.
+func (n number) MarshalYAML() (interface{}, error) {
+	tag := "!!int"
+	if _, err := json.Number(n).Int64(); err != nil {
+		tag = "!!float"
+	}
+
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   tag,
+		Value: string(n),
+	}, nil
+}
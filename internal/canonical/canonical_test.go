@@ -0,0 +1,27 @@
+package canonical_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/tj/assert"
+	"github.com/tj/go-fixture"
+
+	"github.com/newlix/rpc/internal/canonical"
+)
+
+func TestWrite(t *testing.T) {
+	b, err := os.ReadFile("../../examples/todo/schema.json")
+	assert.NoError(t, err, "reading schema")
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			var act bytes.Buffer
+			err = canonical.Write(&act, b, format)
+			assert.NoError(t, err, "writing")
+
+			fixture.Assert(t, "todo."+format, act.Bytes())
+		})
+	}
+}
@@ -0,0 +1,533 @@
+// Package fromgo derives schemas from Go interfaces of RPC methods and the
+// structs of their inputs and outputs.
+package fromgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"golang.org/x/tools/go/packages"
+)
+
+// Config of the schema derived.
+type Config struct {
+	// Dir is the directory in which the package pattern is resolved.
+	Dir string
+
+	// Package is the pattern of the package declaring the interface.
+	Package string
+
+	// Interface is the name of the interface declaring the methods.
+	Interface string
+
+	// Name is the name of the API, defaulting to the package name.
+	Name string
+
+	// Version is the version of the API.
+	Version string
+}
+
+// object is a JSON object of the schema document.
+type object = map[string]interface{}
+
+// Generate returns the JSON schema document of the interface in c. Methods
+// are named in snake case, accepting a context.Context and an optional input
+// struct, and returning an optional output struct and an error.
+func Generate(c Config) ([]byte, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:  c.Dir,
+	}, c.Package)
+	if err != nil {
+		return nil, fmt.Errorf("loading package: %w", err)
+	}
+
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("pattern %q matches %d packages, must match one", c.Package, len(pkgs))
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("loading package: %s", pkg.Errors[0])
+	}
+
+	g := &generator{
+		pkg:   pkg.Types,
+		docs:  docs(pkg.Syntax),
+		types: object{},
+	}
+
+	obj := pkg.Types.Scope().Lookup(c.Interface)
+	if obj == nil {
+		return nil, fmt.Errorf("interface %q is not declared in %s", c.Interface, pkg.PkgPath)
+	}
+
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", c.Interface)
+	}
+
+	var methods []interface{}
+	for i := 0; i < iface.NumMethods(); i++ {
+		m, err := g.method(iface.Method(i))
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", iface.Method(i).Name(), err)
+		}
+		methods = append(methods, m)
+	}
+
+	name := c.Name
+	if name == "" {
+		name = pkg.Name
+	}
+
+	doc := object{
+		"name":    name,
+		"version": c.Version,
+		"methods": methods,
+	}
+
+	// "Service is a to-do list." describes the API as "A to-do list."
+	if s := strings.TrimPrefix(description(g.docs[obj.Pos()], c.Interface), "is "); s != "" {
+		doc["description"] = strings.ToUpper(s[:1]) + s[1:]
+	}
+
+	if len(g.types) > 0 {
+		doc["types"] = g.types
+	}
+
+	return json.Marshal(doc)
+}
+
+// generator of a schema document.
+type generator struct {
+	pkg   *types.Package
+	docs  map[token.Pos]string
+	types object
+}
+
+// method returns the schema method of interface method f.
+func (g *generator) method(f *types.Func) (object, error) {
+	sig := f.Type().(*types.Signature)
+	params, results := sig.Params(), sig.Results()
+
+	m := object{
+		"name":        strcase.ToSnake(f.Name()),
+		"description": description(g.docs[f.Pos()], f.Name()),
+	}
+
+	if params.Len() == 0 || params.Len() > 2 || !isContext(params.At(0).Type()) {
+		return nil, fmt.Errorf("must accept a context.Context and an optional input struct")
+	}
+
+	if results.Len() == 0 || results.Len() > 2 || !isError(results.At(results.Len()-1).Type()) {
+		return nil, fmt.Errorf("must return an optional output struct and an error")
+	}
+
+	if params.Len() == 2 {
+		fields, err := g.fields(params.At(1).Type())
+		if err != nil {
+			return nil, fmt.Errorf("input: %w", err)
+		}
+		m["inputs"] = fields
+	}
+
+	if results.Len() == 2 {
+		fields, err := g.fields(results.At(0).Type())
+		if err != nil {
+			return nil, fmt.Errorf("output: %w", err)
+		}
+		m["outputs"] = fields
+	}
+
+	return m, nil
+}
+
+// fields returns the schema fields of the struct t, or pointer to a struct.
+func (g *generator) fields(t types.Type) ([]interface{}, error) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a struct", t)
+	}
+
+	fields := []interface{}{}
+	for _, jf := range jsonFields(s) {
+		v, tag := jf.v, jf.tag
+		f := object{
+			"name":        jf.name,
+			"description": strings.TrimPrefix(description(g.docs[v.Pos()], v.Name()), "is "),
+		}
+
+		if err := g.fieldType(f, v.Type()); err != nil {
+			return nil, fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+
+		if err := constraints(f, tag.Get("validate")); err != nil {
+			return nil, fmt.Errorf("field %s: %w", v.Name(), err)
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// jsonField is a field of a struct encoded by encoding/json.
+type jsonField struct {
+	v      *types.Var
+	tag    reflect.StructTag
+	name   string
+	tagged bool
+	depth  int
+}
+
+// jsonFields returns the fields of struct s encoded by encoding/json, in
+// order, with the fields of embedded structs promoted unless shadowed.
+func jsonFields(s *types.Struct) (v []jsonField) {
+	all := structFields(s, 0, map[*types.Struct]bool{})
+
+	// the fields named alike at the lowest depth dominate when there is one,
+	// or one tagged, otherwise they are all omitted
+	dominant := map[string]*jsonField{}
+	ambiguous := map[string]bool{}
+	for i := range all {
+		f := &all[i]
+		d, ok := dominant[f.name]
+		switch {
+		case !ok || f.depth < d.depth:
+			dominant[f.name] = f
+			delete(ambiguous, f.name)
+		case f.depth == d.depth && f.tagged == d.tagged:
+			ambiguous[f.name] = true
+		case f.depth == d.depth && f.tagged:
+			dominant[f.name] = f
+			delete(ambiguous, f.name)
+		}
+	}
+
+	for i := range all {
+		if f := &all[i]; dominant[f.name] == f && !ambiguous[f.name] {
+			v = append(v, *f)
+		}
+	}
+
+	return
+}
+
+// structFields returns the fields of struct s at depth, and of the structs
+// it embeds without a json name, skipping those already visited.
+func structFields(s *types.Struct, depth int, visited map[*types.Struct]bool) (v []jsonField) {
+	if visited[s] {
+		return nil
+	}
+	visited[s] = true
+	defer delete(visited, s)
+
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i)
+		tag := reflect.StructTag(s.Tag(i))
+		name := strings.Split(tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous() && name == "" {
+			t := f.Type()
+			p, pointer := t.(*types.Pointer)
+			if pointer {
+				t = p.Elem()
+			}
+
+			// embedded structs are flattened, unless unexported pointers which
+			// encoding/json can't set
+			if e, ok := t.Underlying().(*types.Struct); ok {
+				if f.Exported() || !pointer {
+					v = append(v, structFields(e, depth+1, visited)...)
+				}
+				continue
+			}
+		}
+
+		if !f.Exported() {
+			continue
+		}
+
+		field := jsonField{v: f, tag: tag, name: name, tagged: name != "", depth: depth}
+		if name == "" {
+			field.name = f.Name()
+		}
+		v = append(v, field)
+	}
+
+	return
+}
+
+// fieldType sets the type of field or value definition f to the equivalent of t.
+func (g *generator) fieldType(f object, t types.Type) error {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	if isTime(t) {
+		f["type"] = "timestamp"
+		return nil
+	}
+
+	// named structs and enums are types of the schema
+	if n, ok := t.(*types.Named); ok && n.Obj().Pkg() == g.pkg {
+		ok, err := g.namedType(n)
+		if err != nil {
+			return err
+		}
+		if ok {
+			f["type"] = object{"$ref": "#/types/" + strcase.ToSnake(n.Obj().Name())}
+			return nil
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			f["type"] = "string"
+		case u.Info()&types.IsInteger != 0:
+			f["type"] = "integer"
+		case u.Info()&types.IsFloat != 0:
+			f["type"] = "float"
+		case u.Info()&types.IsBoolean != 0:
+			f["type"] = "boolean"
+		default:
+			return fmt.Errorf("unsupported type %s", t)
+		}
+	case *types.Slice:
+		// byte slices are encoded as base64 strings
+		if b, ok := u.Elem().Underlying().(*types.Basic); ok && b.Kind() == types.Byte {
+			f["type"] = "string"
+			return nil
+		}
+
+		items := object{}
+		if err := g.fieldType(items, u.Elem()); err != nil {
+			return err
+		}
+		f["type"] = "array"
+		f["items"] = value(items)
+	case *types.Map:
+		if b, ok := u.Key().Underlying().(*types.Basic); !ok || b.Info()&types.IsString == 0 {
+			return fmt.Errorf("map keys of %s must be strings", t)
+		}
+		values := object{}
+		if err := g.fieldType(values, u.Elem()); err != nil {
+			return err
+		}
+		f["type"] = "object"
+		f["values"] = value(values)
+	default:
+		return fmt.Errorf("unsupported type %s", t)
+	}
+
+	return nil
+}
+
+// value returns the item or value definition of f, which references types
+// directly instead of through the type property.
+func value(f object) object {
+	if ref, ok := f["type"].(object); ok {
+		delete(f, "type")
+		f["$ref"] = ref["$ref"]
+	}
+	return f
+}
+
+// namedType adds the struct or enum n to the schema types, returning false if
+// n is neither.
+func (g *generator) namedType(n *types.Named) (bool, error) {
+	name := strcase.ToSnake(n.Obj().Name())
+	if _, ok := g.types[name]; ok {
+		return true, nil
+	}
+
+	t := object{}
+	if s := description(g.docs[n.Obj().Pos()], n.Obj().Name()); s != "" {
+		t["description"] = s
+	}
+
+	switch u := n.Underlying().(type) {
+	case *types.Struct:
+		// declared before resolving the fields, which may reference n
+		g.types[name] = t
+		fields, err := g.fields(n)
+		if err != nil {
+			delete(g.types, name)
+			return false, fmt.Errorf("type %s: %w", n.Obj().Name(), err)
+		}
+		t["properties"] = fields
+		return true, nil
+	case *types.Basic:
+		values := g.enum(n)
+		if u.Info()&types.IsString == 0 || len(values) == 0 {
+			return false, nil
+		}
+		t["enum"] = values
+		g.types[name] = t
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// enum returns the values of the string constants of type n, in the order
+// they are declared.
+func (g *generator) enum(n *types.Named) []string {
+	var consts []*types.Const
+	scope := g.pkg.Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok && types.Identical(c.Type(), n) {
+			consts = append(consts, c)
+		}
+	}
+
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	var values []string
+	for _, c := range consts {
+		values = append(values, constant.StringVal(c.Val()))
+	}
+	return values
+}
+
+// constraints sets the constraints of field f from validate-style tag
+// options, such as "required,min=1,max=200".
+func constraints(f object, tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	kind := f["type"]
+	for _, opt := range strings.Split(tag, ",") {
+		key, arg, _ := strings.Cut(opt, "=")
+		switch key {
+		case "required":
+			f["required"] = true
+		case "min", "max":
+			n, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q", key, arg)
+			}
+			switch {
+			case kind == "string" && key == "min":
+				f["minLength"] = int(n)
+			case kind == "string":
+				f["maxLength"] = int(n)
+			case kind != "integer" && kind != "float":
+				return fmt.Errorf("%s requires a string or numeric field", opt)
+			case key == "min":
+				f["minimum"] = n
+			default:
+				f["maximum"] = n
+			}
+		case "email":
+			f["format"] = "email"
+		case "url", "uri":
+			f["format"] = "uri"
+		case "hostname":
+			f["format"] = "hostname"
+		case "oneof":
+			f["enum"] = strings.Fields(arg)
+		}
+	}
+
+	// enum values are constrained by the enum alone
+	if _, ok := f["enum"]; ok {
+		for _, key := range []string{"minLength", "maxLength", "format"} {
+			if _, ok := f[key]; ok {
+				return fmt.Errorf("%s does not apply to oneof fields", key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// docs returns the doc comments of the declarations in files, keyed by the
+// position of their names.
+func docs(files []*ast.File) map[token.Pos]string {
+	m := map[token.Pos]string{}
+
+	add := func(names []*ast.Ident, groups ...*ast.CommentGroup) {
+		for _, g := range groups {
+			if g != nil {
+				for _, n := range names {
+					m[n.Pos()] = g.Text()
+				}
+				return
+			}
+		}
+	}
+
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.GenDecl:
+				for _, spec := range n.Specs {
+					if s, ok := spec.(*ast.TypeSpec); ok {
+						if len(n.Specs) == 1 {
+							add([]*ast.Ident{s.Name}, s.Doc, n.Doc)
+						} else {
+							add([]*ast.Ident{s.Name}, s.Doc)
+						}
+					}
+				}
+			case *ast.Field:
+				add(n.Names, n.Doc, n.Comment)
+			}
+			return true
+		})
+	}
+
+	return m
+}
+
+// description returns the doc comment s of the declaration name as a schema
+// description, without the leading name, as in "adds an item to the list."
+// for "AddItem adds an item to the list.".
+func description(s, name string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.TrimPrefix(s, name+" ")
+}
+
+// isContext returns true if t is context.Context.
+func isContext(t types.Type) bool {
+	return isNamed(t, "context", "Context")
+}
+
+// isTime returns true if t is time.Time.
+func isTime(t types.Type) bool {
+	return isNamed(t, "time", "Time")
+}
+
+// isError returns true if t is the error interface.
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isNamed returns true if t is the type name declared in package path.
+func isNamed(t types.Type, path, name string) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == path && n.Obj().Name() == name
+}
@@ -0,0 +1,63 @@
+package fromgo_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+	"github.com/tj/go-fixture"
+
+	"github.com/newlix/rpc/internal/canonical"
+	"github.com/newlix/rpc/internal/fromgo"
+	"github.com/newlix/rpc/schema"
+)
+
+func TestGenerate(t *testing.T) {
+	b, err := fromgo.Generate(fromgo.Config{
+		Package:   "./testdata/todo",
+		Interface: "Service",
+		Version:   "1.0.0",
+	})
+	assert.NoError(t, err, "generating")
+
+	var act bytes.Buffer
+	err = canonical.Write(&act, b, "json")
+	assert.NoError(t, err, "formatting")
+
+	fixture.Assert(t, "todo.json", act.Bytes())
+
+	path := filepath.Join(t.TempDir(), "schema.json")
+	err = os.WriteFile(path, act.Bytes(), 0644)
+	assert.NoError(t, err, "writing")
+
+	_, err = schema.Load(path)
+	assert.NoError(t, err, "loading")
+}
+
+func TestGenerate_errors(t *testing.T) {
+	t.Run("with an undefined interface", func(t *testing.T) {
+		_, err := fromgo.Generate(fromgo.Config{
+			Package:   "./testdata/todo",
+			Interface: "Store",
+		})
+		assert.EqualError(t, err, `interface "Store" is not declared in github.com/newlix/rpc/internal/fromgo/testdata/todo`)
+	})
+
+	t.Run("with a type which isn't an interface", func(t *testing.T) {
+		_, err := fromgo.Generate(fromgo.Config{
+			Package:   "./testdata/todo",
+			Interface: "Item",
+		})
+		assert.EqualError(t, err, `Item is not an interface`)
+	})
+
+	t.Run("with a constraint of another kind", func(t *testing.T) {
+		_, err := fromgo.Generate(fromgo.Config{
+			Package:   "./testdata/invalid",
+			Interface: "Service",
+		})
+		assert.EqualError(t, err, `method AddTags: input: field Tags: min=1 requires a string or numeric field`)
+	})
+}
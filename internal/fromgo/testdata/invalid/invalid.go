@@ -0,0 +1,18 @@
+// Package invalid is a service with invalid constraints.
+package invalid
+
+import (
+	"context"
+)
+
+// Service is a service with invalid constraints.
+type Service interface {
+	// AddTags adds tags.
+	AddTags(ctx context.Context, in AddTagsInput) error
+}
+
+// AddTagsInput params.
+type AddTagsInput struct {
+	// Tags is the tags to add.
+	Tags []string `json:"tags" validate:"min=1"`
+}
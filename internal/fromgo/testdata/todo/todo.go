@@ -0,0 +1,98 @@
+// Package todo is a to-do list service.
+package todo
+
+import (
+	"context"
+	"time"
+)
+
+// Service is a to-do list.
+type Service interface {
+	// AddItem adds an item to the list.
+	AddItem(ctx context.Context, in AddItemInput) error
+
+	// GetItems returns all items in the list.
+	GetItems(ctx context.Context) (*GetItemsOutput, error)
+
+	// RemoveItem removes an item from the to-do list.
+	RemoveItem(ctx context.Context, in *RemoveItemInput) (*RemoveItemOutput, error)
+}
+
+// Priority is the priority of a to-do item.
+type Priority string
+
+// Priority values.
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+)
+
+// Item is a to-do item.
+type Item struct {
+	// ID is the id of the item.
+	ID int `json:"id"`
+
+	// Text is the to-do item text.
+	Text string `json:"text" validate:"required,max=200"`
+
+	// URL is the link of the to-do item.
+	URL string `json:"url,omitempty" validate:"url"`
+
+	// Priority is the priority of the to-do item.
+	Priority Priority `json:"priority"`
+
+	// Status is the status of the to-do item.
+	Status string `json:"status" validate:"oneof=pending completed"`
+
+	// Labels is the labels of the to-do item, keyed by name.
+	Labels map[string]string `json:"labels"`
+
+	// Attachment is the file attached to the to-do item.
+	Attachment []byte `json:"attachment"`
+
+	// record fields are promoted
+	record
+
+	// internal state is not exposed
+	version int
+}
+
+// record fields of stored types.
+type record struct {
+	// ID is shadowed by the ID of the embedding struct.
+	ID string `json:"id"`
+
+	// CreatedAt is the time the to-do item was created.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddItemInput params.
+type AddItemInput struct {
+	// Item is the item to add.
+	Item string `json:"item" validate:"required,min=1,max=200"`
+
+	// Priority is the priority of the item.
+	Priority Priority `json:"priority"`
+}
+
+// GetItemsOutput params.
+type GetItemsOutput struct {
+	// Items is the list of to-do items.
+	Items []Item `json:"items"`
+
+	// Lists is the to-do items grouped by list name.
+	Lists map[string][]*Item `json:"lists"`
+}
+
+// RemoveItemInput params.
+type RemoveItemInput struct {
+	// ID is the id of the item to remove.
+	ID int `json:"id" validate:"min=1"`
+}
+
+// RemoveItemOutput params.
+type RemoveItemOutput struct {
+	// Item is the item removed.
+	Item Item `json:"item"`
+}
@@ -0,0 +1,144 @@
+// Package gocode assembles generated Go source files, importing the packages
+// referenced by the code and formatting it with gofmt.
+package gocode
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// std is the import path of the standard library packages referenced by the
+// generators, keyed by package name.
+var std = map[string]string{
+	"bytes":   "bytes",
+	"context": "context",
+	"embed":   "embed",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"io":      "io",
+	"json":    "encoding/json",
+	"http":    "net/http",
+	"regexp":  "regexp",
+	"strconv": "strconv",
+	"strings": "strings",
+	"time":    "time",
+	"utf8":    "unicode/utf8",
+}
+
+// version matches the major version suffix of import paths.
+var version = regexp.MustCompile(`^v[0-9]+$`)
+
+// Source returns the formatted Go source file of package pkg with body,
+// importing the standard library packages it references, and those of
+// paths, such as the types package or the packages of mapped types.
+func Source(pkg string, body []byte, paths ...string) ([]byte, error) {
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+	fmt.Fprintf(&src, "package %s\n\n", pkg)
+
+	// parse the body alone to find the packages it references
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", append([]byte(fmt.Sprintf("package %s\n\n", pkg)), body...), parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w", err)
+	}
+
+	known := map[string]string{}
+	for name, p := range std {
+		known[name] = p
+	}
+	for _, p := range paths {
+		known[Name(p)] = p
+	}
+
+	// standard library imports, without a domain, are grouped before the others
+	var stdlib, others []string
+	var embed bool
+	for _, name := range references(f) {
+		embed = embed || name == "embed"
+		p, ok := known[name]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("generated code references unknown package %q", name)
+		case strings.Contains(strings.Split(p, "/")[0], "."):
+			others = append(others, fmt.Sprintf("%q", p))
+		default:
+			stdlib = append(stdlib, fmt.Sprintf("%q", p))
+		}
+	}
+
+	// files embedded as []byte or string still require the package
+	if !embed && bytes.Contains(body, []byte("//go:embed ")) {
+		stdlib = append(stdlib, `_ "embed"`)
+	}
+
+	sort.Strings(stdlib)
+	sort.Strings(others)
+	if len(stdlib) > 0 || len(others) > 0 {
+		fmt.Fprintf(&src, "import (\n")
+		for _, v := range stdlib {
+			fmt.Fprintf(&src, "\t%s\n", v)
+		}
+		if len(stdlib) > 0 && len(others) > 0 {
+			fmt.Fprintf(&src, "\n")
+		}
+		for _, v := range others {
+			fmt.Fprintf(&src, "\t%s\n", v)
+		}
+		fmt.Fprintf(&src, ")\n\n")
+	}
+
+	src.Write(body)
+
+	b, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return b, nil
+}
+
+// Name returns the package name of import path p, its last element without
+// a major version suffix, such as "decimal" for "github.com/shopspring/decimal".
+func Name(p string) string {
+	if name := path.Base(p); !version.MatchString(name) || path.Dir(p) == "." {
+		return strings.TrimSuffix(name, path.Ext(name))
+	}
+	return path.Base(path.Dir(p))
+}
+
+// references returns the sorted names of the packages referenced by f, which
+// are the unresolved identifiers of qualified identifiers such as time.Time.
+func references(f *ast.File) (v []string) {
+	unresolved := map[string]bool{}
+	for _, id := range f.Unresolved {
+		unresolved[id.Name] = true
+	}
+
+	seen := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		id, ok := sel.X.(*ast.Ident)
+		if ok && id.Obj == nil && unresolved[id.Name] && !seen[id.Name] {
+			seen[id.Name] = true
+			v = append(v, id.Name)
+		}
+
+		return true
+	})
+
+	sort.Strings(v)
+	return
+}
@@ -0,0 +1,63 @@
+package gocode_test
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/gocode"
+)
+
+// Test generating source files.
+func TestSource(t *testing.T) {
+	t.Run("without imports", func(t *testing.T) {
+		b, err := gocode.Source("api", []byte("type Item struct {\nID int\n}\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\npackage api\n\ntype Item struct {\n\tID int\n}\n", string(b))
+	})
+
+	t.Run("with imports", func(t *testing.T) {
+		body := "type Item struct {\nID uuid.UUID\nColor color.RGBA\nCreatedAt time.Time\n}\n\nfunc (i *Item) Validate() error { return errors.New(\"invalid\") }\n"
+		b, err := gocode.Source("api", []byte(body), "github.com/google/uuid", "image/color", "gopkg.in/yaml.v3")
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "import (\n\t\"errors\"\n\t\"image/color\"\n\t\"time\"\n\n\t\"github.com/google/uuid\"\n)\n")
+	})
+
+	t.Run("with an embedded file", func(t *testing.T) {
+		b, err := gocode.Source("api", []byte("//go:embed schema.json\nvar schemaJSON []byte\n"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "import (\n\t_ \"embed\"\n)\n")
+	})
+
+	t.Run("with an embedded file system", func(t *testing.T) {
+		b, err := gocode.Source("api", []byte("//go:embed schema.json\nvar schemaFS embed.FS\n"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "import (\n\t\"embed\"\n)\n")
+	})
+
+	t.Run("with local names shadowing packages", func(t *testing.T) {
+		b, err := gocode.Source("api", []byte("func f(time struct{ Now int }) int { return time.Now }\n"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(b), "import")
+	})
+
+	t.Run("with an unknown package", func(t *testing.T) {
+		_, err := gocode.Source("api", []byte("var v foo.Bar\n"))
+		assert.EqualError(t, err, `generated code references unknown package "foo"`)
+	})
+
+	t.Run("with invalid code", func(t *testing.T) {
+		_, err := gocode.Source("api", []byte("func f() { foo( }\n"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "generated code does not parse: ")
+	})
+}
+
+// Test package names of import paths.
+func TestName(t *testing.T) {
+	assert.Equal(t, "time", gocode.Name("time"))
+	assert.Equal(t, "utf8", gocode.Name("unicode/utf8"))
+	assert.Equal(t, "decimal", gocode.Name("github.com/shopspring/decimal"))
+	assert.Equal(t, "yaml", gocode.Name("gopkg.in/yaml.v3"))
+	assert.Equal(t, "chi", gocode.Name("github.com/go-chi/chi/v5"))
+}
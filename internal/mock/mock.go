@@ -0,0 +1,257 @@
+// Package mock serves the methods of a schema with responses from their
+// examples, or synthesized from their output fields.
+package mock
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/newlix/rpc"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// timestamp is the value of synthesized timestamp fields.
+const timestamp = "2006-01-02T15:04:05Z"
+
+// Server is a mock server of the methods in Schema, routing requests like
+// the generated Go server.
+type Server struct {
+	// Schema is the schema of the methods served.
+	Schema *schema.Schema
+
+	// Latency is the delay before responding to each method call.
+	Latency time.Duration
+
+	// ErrorRate is the fraction of method calls, between 0 and 1, responding
+	// with an internal server error.
+	ErrorRate float64
+}
+
+// ServeHTTP implementation.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		switch r.URL.Path {
+		case "/_health":
+			rpc.WriteHealth(w, s)
+		default:
+			rpc.WriteError(w, rpc.BadRequest("Invalid method"))
+		}
+		return
+	}
+
+	if r.Method == "POST" {
+		m, ok := s.method(strings.TrimPrefix(r.URL.Path, "/"))
+		if !ok {
+			rpc.WriteError(w, rpc.BadRequest("Invalid method"))
+			return
+		}
+
+		// deprecation
+		if d := m.Deprecated; d != nil {
+			w.Header().Set("Deprecation", "true")
+			if t, err := time.Parse("2006-01-02", d.Sunset); err == nil {
+				w.Header().Set("Sunset", t.Format(http.TimeFormat))
+			}
+		}
+
+		var in interface{}
+		if len(m.Inputs) > 0 {
+			if err := rpc.ReadRequest(r, &in); err != nil {
+				rpc.WriteError(w, err)
+				return
+			}
+		}
+
+		time.Sleep(s.Latency)
+
+		if s.ErrorRate > 0 && rand.Float64() < s.ErrorRate {
+			rpc.WriteError(w, rpc.Error(http.StatusInternalServerError, "internal", "Injected mock error"))
+			return
+		}
+
+		if len(m.Outputs) == 0 {
+			rpc.WriteResponse(w, nil)
+			return
+		}
+
+		b, err := json.Marshal(Output(s.Schema, m, in))
+		if err != nil {
+			rpc.WriteError(w, err)
+			return
+		}
+
+		rpc.WriteResponse(w, json.RawMessage(b))
+	}
+}
+
+// method returns the method named name.
+func (s *Server) method(name string) (schema.Method, bool) {
+	for _, m := range s.Schema.Methods {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return schema.Method{}, false
+}
+
+// Output returns the output of the first example of method m with an input
+// equal to in, otherwise an output synthesized from the output fields.
+func Output(s *schema.Schema, m schema.Method, in interface{}) interface{} {
+	if in == nil {
+		in = map[string]interface{}{}
+	}
+
+	for _, e := range m.Examples {
+		input := e.Input
+		if input == nil {
+			input = map[string]interface{}{}
+		}
+
+		if reflect.DeepEqual(input, in) {
+			return e.Output
+		}
+	}
+
+	return object(s, m.Outputs, map[string]bool{})
+}
+
+// object returns a value for the fields, skipping optional fields
+// referencing the types already being synthesized.
+func object(s *schema.Schema, fields []schema.Field, visiting map[string]bool) map[string]interface{} {
+	o := map[string]interface{}{}
+	for _, f := range fields {
+		v, ok := value(s, f, visiting)
+		if ok {
+			o[f.Name] = v
+		}
+	}
+	return o
+}
+
+// value returns a value for field f, or false if it would recurse.
+func value(s *schema.Schema, f schema.Field, visiting map[string]bool) (interface{}, bool) {
+	if f.Default != nil {
+		return f.Default, true
+	}
+
+	if f.Type.Ref.Value != "" {
+		t := schemautil.ResolveRef(s, f.Type.Ref)
+		if visiting[t.Name] && !f.Required {
+			return nil, false
+		}
+		return typeValue(s, t, visiting), true
+	}
+
+	switch f.Type.Type {
+	case schema.String:
+		return stringValue(f), true
+	case schema.Int:
+		return number(f, 1, true), true
+	case schema.Float:
+		return number(f, 1.5, false), true
+	case schema.Bool:
+		return true, true
+	case schema.Timestamp:
+		return timestamp, true
+	case schema.Array:
+		v, ok := value(s, f.Items.Field(), visiting)
+		if !ok {
+			return []interface{}{}, true
+		}
+		return []interface{}{v}, true
+	case schema.Object:
+		if !f.HasValues() {
+			return map[string]interface{}{}, true
+		}
+		v, ok := value(s, f.Values.Field(), visiting)
+		if !ok {
+			return map[string]interface{}{}, true
+		}
+		return map[string]interface{}{"key": v}, true
+	default:
+		return nil, true
+	}
+}
+
+// number returns v within the minimum and maximum of field f, rounded
+// towards the range when integer.
+func number(f schema.Field, v float64, integer bool) float64 {
+	if n := f.Minimum; n != nil && v < *n {
+		v = *n
+		if integer {
+			v = math.Ceil(v)
+		}
+	}
+
+	if n := f.Maximum; n != nil && v > *n {
+		v = *n
+		if integer {
+			v = math.Floor(v)
+		}
+	}
+
+	return v
+}
+
+// typeValue returns the first example of type t, otherwise a value
+// synthesized from its properties or first variant.
+func typeValue(s *schema.Schema, t schema.Type, visiting map[string]bool) interface{} {
+	if len(t.Examples) > 0 {
+		return t.Examples[0].Value
+	}
+
+	switch {
+	case t.IsEnum():
+		return t.Enum[0]
+	case t.IsUnion():
+		variant := schemautil.ResolveRef(s, t.OneOf[0])
+		o := map[string]interface{}{
+			t.Discriminator: variant.Name,
+		}
+		v, _ := typeValue(s, variant, visiting).(map[string]interface{})
+		for k, x := range v {
+			o[k] = x
+		}
+		return o
+	default:
+		visiting[t.Name] = true
+		o := object(s, t.Properties, visiting)
+		visiting[t.Name] = false
+		return o
+	}
+}
+
+// stringValue returns a value for the string field f.
+func stringValue(f schema.Field) string {
+	if f.Enum != nil {
+		return f.Enum[0]
+	}
+
+	var v string
+	switch f.Format {
+	case schema.Email:
+		v = "user@example.com"
+	case schema.URI:
+		v = "https://example.com"
+	case schema.Hostname:
+		v = "example.com"
+	default:
+		v = f.Name
+	}
+
+	if f.MinLength != nil && len(v) < *f.MinLength {
+		v += strings.Repeat("x", *f.MinLength-len(v))
+	}
+
+	if f.MaxLength != nil && len(v) > *f.MaxLength {
+		v = v[:*f.MaxLength]
+	}
+
+	return v
+}
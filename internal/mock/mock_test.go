@@ -0,0 +1,90 @@
+package mock_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/mock"
+	"github.com/newlix/rpc/schema"
+)
+
+// call method of h with body, returning the response.
+func call(h http.Handler, method, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/"+method, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestServer(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	t.Run("without outputs", func(t *testing.T) {
+		w := call(&mock.Server{Schema: s}, "add_item", `{ "item": "Buy milk", "priority": "high" }`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("with synthesized outputs", func(t *testing.T) {
+		w := call(&mock.Server{Schema: s}, "remove_item", `{ "id": 5 }`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Tue, 01 Jun 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+		assert.JSONEq(t, `{
+			"item": {
+				"id": 1,
+				"text": "Buy milk",
+				"status": "pending",
+				"priority": "normal",
+				"reminder": {
+					"type": "time_reminder",
+					"at": "2026-10-19T09:00:00Z"
+				},
+				"created_at": "2026-10-18T12:00:00Z"
+			}
+		}`, w.Body.String())
+	})
+
+	t.Run("with an invalid method", func(t *testing.T) {
+		w := call(&mock.Server{Schema: s}, "remove_items", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		w := call(&mock.Server{Schema: s, ErrorRate: 1}, "get_items", `{}`)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.JSONEq(t, `{ "type": "internal", "message": "Injected mock error" }`, w.Body.String())
+	})
+}
+
+func TestOutput(t *testing.T) {
+	s, err := schema.Load("testdata/users.json")
+	assert.NoError(t, err, "loading schema")
+
+	m := s.Methods[0]
+
+	t.Run("with a matching example", func(t *testing.T) {
+		v := mock.Output(s, m, map[string]interface{}{"limit": 1.0})
+		assert.Equal(t, m.Examples[0].Output, v)
+	})
+
+	t.Run("without a matching example", func(t *testing.T) {
+		v := mock.Output(s, m, map[string]interface{}{"limit": 5.0})
+		assert.Equal(t, map[string]interface{}{
+			"users": []interface{}{
+				map[string]interface{}{
+					"name":     "namexxxx",
+					"email":    "user@example.com",
+					"balance":  0.0,
+					"discount": 1.0,
+					"role":     "admin",
+				},
+			},
+		}, v)
+	})
+}
@@ -0,0 +1,172 @@
+package schemautil
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// Check kinds.
+const (
+	MinLength = "minLength"
+	MaxLength = "maxLength"
+	Pattern   = "pattern"
+	Format    = "format"
+	Minimum   = "minimum"
+	Maximum   = "maximum"
+)
+
+// formats is the pattern of each string format, kept simple so that
+// every target language agrees on the result.
+var formats = map[schema.Format]string{
+	schema.Email:    `^[^@\s]+@[^@\s]+\.[^@\s]+$`,
+	schema.URI:      `^[a-zA-Z][a-zA-Z0-9+.-]*:\S+$`,
+	schema.Hostname: `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`,
+}
+
+// formatNames is the description of each string format.
+var formatNames = map[schema.Format]string{
+	schema.Email:    "email address",
+	schema.URI:      "URI",
+	schema.Hostname: "hostname",
+}
+
+// Check is a validation check of a field value.
+type Check struct {
+	// Kind is the kind of check.
+	Kind string
+
+	// Length is the limit of MinLength and MaxLength checks.
+	Length int
+
+	// Pattern is the regular expression of Pattern and Format checks.
+	Pattern string
+
+	// Number is the limit of Minimum and Maximum checks, formatted as a literal.
+	Number string
+
+	// Message is the error message when the check fails.
+	Message string
+}
+
+// Checks returns the validation checks of field f.
+func Checks(f schema.Field) (v []Check) {
+	if n := f.MinLength; n != nil {
+		v = append(v, Check{
+			Kind:    MinLength,
+			Length:  *n,
+			Message: fmt.Sprintf("must be at least %d %s long", *n, characters(*n)),
+		})
+	}
+
+	if n := f.MaxLength; n != nil {
+		v = append(v, Check{
+			Kind:    MaxLength,
+			Length:  *n,
+			Message: fmt.Sprintf("must be at most %d %s long", *n, characters(*n)),
+		})
+	}
+
+	if f.Pattern != "" {
+		v = append(v, Check{
+			Kind:    Pattern,
+			Pattern: f.Pattern,
+			Message: fmt.Sprintf("must match the pattern %s", f.Pattern),
+		})
+	}
+
+	if f.Format != "" {
+		v = append(v, Check{
+			Kind:    Format,
+			Pattern: formats[f.Format],
+			Message: fmt.Sprintf("must be a valid %s", formatNames[f.Format]),
+		})
+	}
+
+	if n := f.Minimum; n != nil {
+		v = append(v, Check{
+			Kind:    Minimum,
+			Number:  strconv.FormatFloat(*n, 'f', -1, 64),
+			Message: fmt.Sprintf("must be at least %s", strconv.FormatFloat(*n, 'f', -1, 64)),
+		})
+	}
+
+	if n := f.Maximum; n != nil {
+		v = append(v, Check{
+			Kind:    Maximum,
+			Number:  strconv.FormatFloat(*n, 'f', -1, 64),
+			Message: fmt.Sprintf("must be at most %s", strconv.FormatFloat(*n, 'f', -1, 64)),
+		})
+	}
+
+	return
+}
+
+// Validates returns true if any of the fields, or the types they
+// reference directly or as array items, have validation checks.
+func Validates(s *schema.Schema, fields []schema.Field) bool {
+	return validates(s, fields, map[string]bool{})
+}
+
+// validates implementation, skipping types already seen.
+func validates(s *schema.Schema, fields []schema.Field, seen map[string]bool) bool {
+	for _, f := range fields {
+		if len(Checks(f)) > 0 {
+			return true
+		}
+
+		if t, ok := ValidatedRef(s, f); ok && !seen[t.Name] {
+			seen[t.Name] = true
+			if validates(s, t.Properties, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidatedRef returns the struct type field f references directly or as
+// array items, which may have validation checks of its own.
+func ValidatedRef(s *schema.Schema, f schema.Field) (schema.Type, bool) {
+	ref := f.Type.Ref
+	if f.Type.Type == schema.Array {
+		ref = f.Items.Ref
+	}
+
+	if ref.Value == "" {
+		return schema.Type{}, false
+	}
+
+	t := ResolveRef(s, ref)
+	if t.IsEnum() || t.IsUnion() {
+		return schema.Type{}, false
+	}
+
+	return t, true
+}
+
+// characters returns the unit of a length of n.
+func characters(n int) string {
+	if n == 1 {
+		return "character"
+	}
+	return "characters"
+}
+
+// Nullable returns true if field f is optional and validated without a
+// default, so that targets must distinguish its absence from a zero value,
+// which would otherwise either be checked or skip the checks.
+func Nullable(s *schema.Schema, f schema.Field) bool {
+	if f.Required || f.Default != nil || f.Type.Type == schema.Array {
+		return false
+	}
+
+	if len(Checks(f)) > 0 {
+		return true
+	}
+
+	t, ok := ValidatedRef(s, f)
+	return ok && Validates(s, t.Properties)
+}
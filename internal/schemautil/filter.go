@@ -0,0 +1,207 @@
+package schemautil
+
+import (
+	"fmt"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// Visibilities available.
+const (
+	// Public includes the methods which are not private.
+	Public = "public"
+
+	// Internal includes the private methods.
+	Internal = "internal"
+
+	// All includes every method.
+	All = "all"
+)
+
+// Filter returns a copy of s with only the methods of the given visibility,
+// the types they reference, and the unreferenced types of that visibility
+// along with the types those reference. Types referenced only by the excluded
+// methods are removed.
+func Filter(s *schema.Schema, visibility string) (*schema.Schema, error) {
+	visible := func(private bool) bool {
+		if visibility == Internal {
+			return private
+		}
+		return !private
+	}
+
+	switch visibility {
+	case Public, Internal:
+	case All:
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unsupported visibility %q, must be one of: public, internal, all", visibility)
+	}
+
+	c := filter(s, func(m schema.Method) bool {
+		return visible(m.Private)
+	}, func(t schema.Type) bool {
+		return visible(t.Private)
+	})
+
+	// public methods must not expose private types
+	if visibility == Public {
+		for _, m := range c.Methods {
+			names := map[string]bool{}
+			references(s, append(append([]schema.Field{}, m.Inputs...), m.Outputs...), names)
+			for _, t := range s.TypesSlice() {
+				if names[t.Name] && t.Private {
+					return nil, fmt.Errorf("method %q references private type %q", m.Name, t.Name)
+				}
+			}
+		}
+
+		// nor public types, which are kept even when unreferenced
+		for _, t := range c.TypesSlice() {
+			if t.Private {
+				continue
+			}
+			names := map[string]bool{}
+			typeReferences(s, t, names)
+			for _, r := range s.TypesSlice() {
+				if names[r.Name] && r.Private {
+					return nil, fmt.Errorf("type %q references private type %q", t.Name, r.Name)
+				}
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// FilterGroups returns a copy of s with only the methods of the named groups,
+// the types they reference, and the unreferenced types. Types referenced only
+// by the excluded methods are removed.
+func FilterGroups(s *schema.Schema, names []string) (*schema.Schema, error) {
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+
+	for _, name := range names {
+		if _, ok := Group(s, name); !ok {
+			return nil, fmt.Errorf("undefined group %q", name)
+		}
+	}
+
+	return filter(s, func(m schema.Method) bool {
+		return selected[m.Group]
+	}, func(t schema.Type) bool {
+		return true
+	}), nil
+}
+
+// Group returns the group of s with the given name.
+func Group(s *schema.Schema, name string) (schema.Group, bool) {
+	for _, g := range s.Groups {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return schema.Group{}, false
+}
+
+// Groups returns the groups of s which have methods, in order.
+func Groups(s *schema.Schema) (v []schema.Group) {
+	for _, g := range s.Groups {
+		if len(GroupMethods(s, g.Name)) > 0 {
+			v = append(v, g)
+		}
+	}
+	return
+}
+
+// GroupMethods returns the methods of s in the named group, or the
+// ungrouped methods when name is empty.
+func GroupMethods(s *schema.Schema, name string) (v []schema.Method) {
+	for _, m := range s.Methods {
+		if m.Group == name {
+			v = append(v, m)
+		}
+	}
+	return
+}
+
+// filter returns a copy of s with the methods matching method, the types
+// they reference, and the unreferenced types matching typ.
+func filter(s *schema.Schema, method func(schema.Method) bool, typ func(schema.Type) bool) *schema.Schema {
+	c := *s
+	c.Methods = nil
+	c.Groups = nil
+	c.Types = map[string]schema.Type{}
+
+	// methods & the types they reference
+	all := map[string]bool{}
+	kept := map[string]bool{}
+	groups := map[string]bool{}
+	for _, m := range s.Methods {
+		fields := append(append([]schema.Field{}, m.Inputs...), m.Outputs...)
+		references(s, fields, all)
+
+		if !method(m) {
+			continue
+		}
+
+		c.Methods = append(c.Methods, m)
+		groups[m.Group] = true
+		references(s, fields, kept)
+	}
+
+	// unreferenced types & the types they reference
+	for name, t := range s.Types {
+		if !kept[name] && !all[name] && typ(t) {
+			kept[name] = true
+			typeReferences(s, t, kept)
+		}
+	}
+
+	// types
+	for name := range kept {
+		c.Types[name] = s.Types[name]
+	}
+
+	// groups of the remaining methods
+	for _, g := range s.Groups {
+		if groups[g.Name] {
+			c.Groups = append(c.Groups, g)
+		}
+	}
+
+	return &c
+}
+
+// references adds the names of the types referenced by fields to names,
+// including the types those types reference in turn.
+func references(s *schema.Schema, fields []schema.Field, names map[string]bool) {
+	for _, f := range fields {
+		for _, ref := range fieldRefs(f) {
+			reference(s, ref, names)
+		}
+	}
+}
+
+// reference adds the name of the type referenced by ref to names, including
+// the types it references in turn.
+func reference(s *schema.Schema, ref schema.Ref, names map[string]bool) {
+	name := refName(ref)
+	if names[name] {
+		return
+	}
+
+	names[name] = true
+	typeReferences(s, s.Types[name], names)
+}
+
+// typeReferences adds the names of the types referenced by the properties or
+// variants of t to names, including the types those types reference in turn.
+func typeReferences(s *schema.Schema, t schema.Type, names map[string]bool) {
+	references(s, t.Properties, names)
+	for _, ref := range t.OneOf {
+		reference(s, ref, names)
+	}
+}
@@ -0,0 +1,129 @@
+package schemautil_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test filtering methods and types by visibility.
+func TestFilter(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	t.Run("public", func(t *testing.T) {
+		c, err := schemautil.Filter(s, schemautil.Public)
+		assert.NoError(t, err, "filtering")
+		assert.Equal(t, []string{"add_item", "get_items", "remove_item", "update_item"}, methods(c))
+		assert.Equal(t, []string{"item", "location_reminder", "priority", "reminder", "time_reminder"}, types(c))
+	})
+
+	t.Run("internal", func(t *testing.T) {
+		c, err := schemautil.Filter(s, schemautil.Internal)
+		assert.NoError(t, err, "filtering")
+		assert.Equal(t, []string{"get_stats"}, methods(c))
+		assert.Equal(t, []string{"stats"}, types(c))
+	})
+
+	t.Run("all", func(t *testing.T) {
+		c, err := schemautil.Filter(s, schemautil.All)
+		assert.NoError(t, err, "filtering")
+		assert.Equal(t, methods(s), methods(c))
+		assert.Equal(t, types(s), types(c))
+	})
+
+	t.Run("public method with a private type", func(t *testing.T) {
+		p := *s
+		p.Types = map[string]schema.Type{}
+		for k, v := range s.Types {
+			p.Types[k] = v
+		}
+		item := p.Types["item"]
+		item.Private = true
+		p.Types["item"] = item
+
+		_, err := schemautil.Filter(&p, schemautil.Public)
+		assert.EqualError(t, err, `method "get_items" references private type "item"`)
+	})
+
+	t.Run("public type with a private type", func(t *testing.T) {
+		p := *s
+		p.Types = map[string]schema.Type{}
+		for k, v := range s.Types {
+			p.Types[k] = v
+		}
+		p.Types["pub"] = schema.Type{
+			Name: "pub",
+			Properties: []schema.Field{
+				{Name: "stats", Type: schema.TypeObject{Ref: schema.Ref{Value: "#/types/stats"}}},
+			},
+		}
+
+		_, err := schemautil.Filter(&p, schemautil.Public)
+		assert.EqualError(t, err, `type "pub" references private type "stats"`)
+	})
+
+	t.Run("private type with a public type", func(t *testing.T) {
+		p := *s
+		p.Types = map[string]schema.Type{}
+		for k, v := range s.Types {
+			p.Types[k] = v
+		}
+		p.Types["audit"] = schema.Type{
+			Name:    "audit",
+			Private: true,
+			Properties: []schema.Field{
+				{Name: "item", Type: schema.TypeObject{Ref: schema.Ref{Value: "#/types/item"}}},
+			},
+		}
+
+		c, err := schemautil.Filter(&p, schemautil.Internal)
+		assert.NoError(t, err, "filtering")
+		assert.Equal(t, []string{"audit", "item", "location_reminder", "priority", "reminder", "stats", "time_reminder"}, types(c))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := schemautil.Filter(s, "secret")
+		assert.EqualError(t, err, `unsupported visibility "secret", must be one of: public, internal, all`)
+	})
+}
+
+// Test filtering methods and types by group.
+func TestFilterGroups(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	t.Run("selected", func(t *testing.T) {
+		c, err := schemautil.FilterGroups(s, []string{"items"})
+		assert.NoError(t, err, "filtering")
+		assert.Equal(t, []string{"add_item", "get_items", "remove_item", "update_item"}, methods(c))
+		assert.Equal(t, []string{"item", "location_reminder", "priority", "reminder", "time_reminder"}, types(c))
+		assert.Len(t, c.Groups, 1)
+	})
+
+	t.Run("undefined", func(t *testing.T) {
+		_, err := schemautil.FilterGroups(s, []string{"lists"})
+		assert.EqualError(t, err, `undefined group "lists"`)
+	})
+}
+
+// methods returns the method names of s.
+func methods(s *schema.Schema) (v []string) {
+	for _, m := range s.Methods {
+		v = append(v, m.Name)
+	}
+	return
+}
+
+// types returns the sorted type names of s.
+func types(s *schema.Schema) (v []string) {
+	for name := range s.Types {
+		v = append(v, name)
+	}
+	sort.Strings(v)
+	return
+}
@@ -0,0 +1,220 @@
+package schemautil
+
+import (
+	"strings"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// InputName returns the name of the input variant of type name.
+func InputName(name string) string {
+	return name + "_input"
+}
+
+// Owner returns the name of the type declaring the fields of t, which is
+// the original type of input variants.
+func Owner(t schema.Type) string {
+	if t.InputOf != "" {
+		return t.InputOf
+	}
+	return t.Name
+}
+
+// Inputs returns a copy of s where method inputs reference input variants of
+// the types with read-only fields, directly or through the types they
+// reference. The variants are declared as types named by InputName, without
+// the read-only fields. Unions and their variants are left as-is, as their
+// discriminator is the variant type name.
+func Inputs(s *schema.Schema) *schema.Schema {
+	needs := map[string]bool{}
+
+	// types with read-only fields
+	for name, t := range s.Types {
+		for _, f := range t.Properties {
+			if f.ReadOnly && !t.IsUnion() {
+				needs[name] = true
+			}
+		}
+	}
+
+	// types referencing them, until there are no more
+	for changed := true; changed; {
+		changed = false
+		for name, t := range s.Types {
+			if needs[name] || t.IsEnum() || t.IsUnion() {
+				continue
+			}
+
+			for _, f := range t.Properties {
+				for _, ref := range fieldRefs(f) {
+					if needs[refName(ref)] && !needs[name] {
+						needs[name] = true
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	// variants referenced by method inputs
+	used := map[string]bool{}
+	var use func(fields []schema.Field)
+	use = func(fields []schema.Field) {
+		for _, f := range fields {
+			for _, ref := range fieldRefs(f) {
+				name := refName(ref)
+				if needs[name] && !used[name] {
+					used[name] = true
+					use(s.Types[name].Properties)
+				}
+			}
+		}
+	}
+
+	for _, m := range s.Methods {
+		use(m.Inputs)
+	}
+
+	c := *s
+	c.Types = map[string]schema.Type{}
+	for name, t := range s.Types {
+		c.Types[name] = t
+	}
+
+	// input variants
+	for name, t := range s.Types {
+		if !used[name] {
+			continue
+		}
+
+		v := t
+		v.Name = InputName(name)
+		v.InputOf = name
+		v.Description = strings.TrimSpace(t.Description + " Read-only fields are omitted.")
+		v.Examples = nil
+		v.Properties = nil
+		for _, f := range t.Properties {
+			if !f.ReadOnly {
+				v.Properties = append(v.Properties, inputField(f, needs))
+			}
+		}
+		c.Types[v.Name] = v
+	}
+
+	// method inputs
+	c.Methods = nil
+	for _, m := range s.Methods {
+		inputs := m.Inputs
+		m.Inputs = nil
+		for _, f := range inputs {
+			m.Inputs = append(m.Inputs, inputField(f, needs))
+		}
+		c.Methods = append(c.Methods, m)
+	}
+
+	return &c
+}
+
+// inputField returns field f referencing the input variants of the types in needs.
+func inputField(f schema.Field, needs map[string]bool) schema.Field {
+	f.Type.Ref = inputRef(f.Type.Ref, needs)
+	f.Items = inputItems(f.Items, needs)
+	f.Values = inputItems(f.Values, needs)
+	return f
+}
+
+// inputItems returns item i referencing the input variants of the types in needs.
+func inputItems(i schema.ItemsObject, needs map[string]bool) schema.ItemsObject {
+	i.Ref = inputRef(i.Ref, needs)
+
+	if i.Items != nil {
+		items := inputItems(*i.Items, needs)
+		i.Items = &items
+	}
+
+	if i.Values != nil {
+		values := inputItems(*i.Values, needs)
+		i.Values = &values
+	}
+
+	return i
+}
+
+// inputRef returns the reference to the input variant of ref, if any.
+func inputRef(ref schema.Ref, needs map[string]bool) schema.Ref {
+	if name := refName(ref); needs[name] {
+		return schema.Ref{Value: "#/types/" + InputName(name)}
+	}
+	return ref
+}
+
+// fieldRefs returns the references of field f, including those of its
+// array items and object values.
+func fieldRefs(f schema.Field) (v []schema.Ref) {
+	if f.Type.Ref.Value != "" {
+		v = append(v, f.Type.Ref)
+	}
+
+	if f.Items.Type != "" || f.Items.Ref.Value != "" {
+		v = append(v, fieldRefs(f.Items.Field())...)
+	}
+
+	if f.HasValues() {
+		v = append(v, fieldRefs(f.Values.Field())...)
+	}
+
+	return
+}
+
+// refName returns the name of the type referenced by ref.
+func refName(ref schema.Ref) string {
+	return strings.Replace(ref.Value, "#/types/", "", 1)
+}
+
+// ReadOnlyPaths returns the paths of the read-only fields in fields and the
+// types they reference, in the format of rpc.ReadRequestRejecting. Unions
+// are not followed, as their variants keep their read-only fields.
+func ReadOnlyPaths(s *schema.Schema, fields []schema.Field) []string {
+	return readOnlyPaths(s, "", fields, map[string]bool{})
+}
+
+// readOnlyPaths implementation, skipping the types already on the path.
+func readOnlyPaths(s *schema.Schema, prefix string, fields []schema.Field, visiting map[string]bool) (v []string) {
+	// value returns the paths within the value of field f at path.
+	var value func(path string, f schema.Field) []string
+	value = func(path string, f schema.Field) (v []string) {
+		if ref := f.Type.Ref; ref.Value != "" {
+			name := refName(ref)
+			t := s.Types[name]
+			if visiting[name] || t.IsUnion() {
+				return nil
+			}
+
+			visiting[name] = true
+			v = readOnlyPaths(s, path+".", t.Properties, visiting)
+			visiting[name] = false
+			return v
+		}
+
+		switch f.Type.Type {
+		case schema.Array:
+			return value(path+"[]", f.Items.Field())
+		case schema.Object:
+			if f.HasValues() {
+				return value(path+".*", f.Values.Field())
+			}
+		}
+
+		return nil
+	}
+
+	for _, f := range fields {
+		if f.ReadOnly {
+			v = append(v, prefix+f.Name)
+			continue
+		}
+		v = append(v, value(prefix+f.Name, f)...)
+	}
+
+	return
+}
@@ -0,0 +1,54 @@
+package schemautil_test
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test input variants of types with read-only fields.
+func TestInputs(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	c := schemautil.Inputs(s)
+	assert.Equal(t, []string{"item", "item_input", "location_reminder", "priority", "reminder", "stats", "time_reminder"}, types(c))
+
+	input := c.Types["item_input"]
+	assert.Equal(t, "item", input.InputOf)
+	assert.Equal(t, "item", schemautil.Owner(input))
+	for _, f := range input.Properties {
+		assert.False(t, f.ReadOnly, "field %s", f.Name)
+		assert.NotEqual(t, "id", f.Name)
+		assert.NotEqual(t, "created_at", f.Name)
+	}
+
+	var m schema.Method
+	for _, v := range c.Methods {
+		if v.Name == "update_item" {
+			m = v
+		}
+	}
+	assert.Equal(t, "#/types/item_input", m.Inputs[1].Type.Ref.Value)
+	assert.Equal(t, "#/types/item", m.Outputs[0].Type.Ref.Value)
+}
+
+// Test paths of read-only fields.
+func TestReadOnlyPaths(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	for _, m := range s.Methods {
+		if m.Name == "update_item" {
+			assert.Equal(t, []string{"item.created_at", "item.id"}, schemautil.ReadOnlyPaths(s, m.Inputs))
+		}
+	}
+
+	fields := []schema.Field{
+		{Name: "items", Type: schema.TypeObject{Type: schema.Array}, Items: schema.ItemsObject{Ref: schema.Ref{Value: "#/types/item"}}},
+	}
+	assert.Equal(t, []string{"items[].created_at", "items[].id"}, schemautil.ReadOnlyPaths(s, fields))
+}
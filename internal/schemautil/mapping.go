@@ -0,0 +1,156 @@
+package schemautil
+
+import (
+	"sort"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// Target languages of type mappings.
+const (
+	Go     = "go"
+	TS     = "ts"
+	Swift  = "swift"
+	Kotlin = "kotlin"
+)
+
+// Mapping returns the type of language lang used in place of the generated
+// type of field f, which is the mapping of the field itself, otherwise of the
+// type it references, otherwise of its kind in the schema options. Inline
+// enums are only mapped by the field itself.
+func Mapping(s *schema.Schema, lang string, f schema.Field) (schema.Mapping, bool) {
+	if m := mappingOf(f.Mappings, lang); m != nil {
+		return *m, true
+	}
+
+	if f.Type.Ref.Value != "" {
+		return TypeMapping(lang, ResolveRef(s, f.Type.Ref))
+	}
+
+	if IsInlineEnum(f) {
+		return schema.Mapping{}, false
+	}
+
+	m, ok := kinds(s, lang)[f.Type.Type]
+	return m, ok
+}
+
+// TypeMapping returns the type of language lang used in place of the
+// generated type t, if any. Mapped types are not generated.
+func TypeMapping(lang string, t schema.Type) (schema.Mapping, bool) {
+	if m := mappingOf(t.Mappings, lang); m != nil {
+		return *m, true
+	}
+	return schema.Mapping{}, false
+}
+
+// IsMapped returns true if field f, or the type it references directly or as
+// array items, has a type of language lang used in place of the generated
+// type. Mapped fields are not validated.
+func IsMapped(s *schema.Schema, lang string, f schema.Field) bool {
+	if _, ok := Mapping(s, lang, f); ok {
+		return true
+	}
+
+	if f.Type.Type == schema.Array {
+		_, ok := Mapping(s, lang, f.Items.Field())
+		return ok
+	}
+
+	return false
+}
+
+// Mappings returns the type mappings of language lang used by the fields of
+// s which declare an import, sorted by import and type.
+func Mappings(s *schema.Schema, lang string) (v []schema.Mapping) {
+	seen := map[schema.Mapping]bool{}
+
+	var visit func(f schema.Field)
+	visit = func(f schema.Field) {
+		if m, ok := Mapping(s, lang, f); ok {
+			if m.Import != "" && !seen[m] {
+				seen[m] = true
+				v = append(v, m)
+			}
+			return
+		}
+
+		if f.Items.Type != "" || f.Items.Ref.Value != "" {
+			visit(f.Items.Field())
+		}
+
+		if f.HasValues() {
+			visit(f.Values.Field())
+		}
+	}
+
+	for _, m := range s.Methods {
+		for _, f := range m.Inputs {
+			visit(f)
+		}
+		for _, f := range m.Outputs {
+			visit(f)
+		}
+	}
+
+	for _, t := range s.Types {
+		if _, ok := TypeMapping(lang, t); ok {
+			continue
+		}
+		for _, f := range t.Properties {
+			visit(f)
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		if v[i].Import != v[j].Import {
+			return v[i].Import < v[j].Import
+		}
+		return v[i].Type < v[j].Type
+	})
+
+	return
+}
+
+// Imports returns the unique imports of the type mappings of language lang
+// used by s, sorted.
+func Imports(s *schema.Schema, lang string) (v []string) {
+	for _, m := range Mappings(s, lang) {
+		if len(v) == 0 || v[len(v)-1] != m.Import {
+			v = append(v, m.Import)
+		}
+	}
+	return
+}
+
+// mappingOf returns the mapping of language lang in m, if any.
+func mappingOf(m schema.Mappings, lang string) *schema.Mapping {
+	switch lang {
+	case Go:
+		return m.Go
+	case TS:
+		return m.TS
+	case Swift:
+		return m.Swift
+	case Kotlin:
+		return m.Kotlin
+	default:
+		return nil
+	}
+}
+
+// kinds returns the type mappings of language lang keyed by kind.
+func kinds(s *schema.Schema, lang string) map[schema.Kind]schema.Mapping {
+	switch lang {
+	case Go:
+		return s.Go.Types
+	case TS:
+		return s.TS.Types
+	case Swift:
+		return s.Swift.Types
+	case Kotlin:
+		return s.Kotlin.Types
+	default:
+		return nil
+	}
+}
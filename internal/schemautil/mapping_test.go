@@ -0,0 +1,54 @@
+package schemautil_test
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test type mappings of fields, types and kinds.
+func TestMapping(t *testing.T) {
+	s, err := schema.Load("../../schema/testdata/mapping.json")
+	assert.NoError(t, err, "loading")
+
+	product := s.Types["product"]
+	id := s.Methods[0].Inputs[0]
+
+	t.Run("of a field", func(t *testing.T) {
+		m, ok := schemautil.Mapping(s, schemautil.Go, id)
+		assert.True(t, ok)
+		assert.Equal(t, "uuid.UUID", m.Type)
+
+		_, ok = schemautil.Mapping(s, schemautil.TS, id)
+		assert.False(t, ok)
+	})
+
+	t.Run("of a type", func(t *testing.T) {
+		colors := product.Properties[0]
+		assert.Equal(t, "colors", colors.Name)
+
+		m, ok := schemautil.Mapping(s, schemautil.Go, colors.Items.Field())
+		assert.True(t, ok)
+		assert.Equal(t, "color.RGBA", m.Type)
+		assert.True(t, schemautil.IsMapped(s, schemautil.Go, colors))
+	})
+
+	t.Run("of a kind", func(t *testing.T) {
+		price := product.Properties[3]
+		assert.Equal(t, "price", price.Name)
+
+		m, ok := schemautil.Mapping(s, schemautil.Kotlin, price)
+		assert.True(t, ok)
+		assert.Equal(t, "BigDecimal.ZERO", m.Zero)
+		assert.False(t, schemautil.IsMapped(s, schemautil.Go, product.Properties[2]))
+	})
+
+	t.Run("imports", func(t *testing.T) {
+		assert.Equal(t, []string{"github.com/google/uuid", "github.com/shopspring/decimal", "image/color"}, schemautil.Imports(s, schemautil.Go))
+		assert.Equal(t, []string{"decimal.js"}, schemautil.Imports(s, schemautil.TS))
+		assert.Empty(t, schemautil.Imports(s, schemautil.Swift))
+	})
+}
@@ -1,7 +1,9 @@
 package schemautil
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/newlix/rpc/schema"
@@ -20,9 +22,95 @@ func ResolveRef(s *schema.Schema, ref schema.Ref) schema.Type {
 	panic(fmt.Sprintf("reference to undefined type %q", ref.Value))
 }
 
+// Variants returns the resolved variant types of union t.
+func Variants(s *schema.Schema, t schema.Type) (v []schema.Type) {
+	for _, ref := range t.OneOf {
+		v = append(v, ResolveRef(s, ref))
+	}
+	return
+}
+
+// Unions returns the union types which t is a variant of.
+func Unions(s *schema.Schema, t schema.Type) (v []schema.Type) {
+	for _, u := range s.TypesSlice() {
+		for _, variant := range Variants(s, u) {
+			if variant.Name == t.Name {
+				v = append(v, u)
+			}
+		}
+	}
+	return
+}
+
+// IsInlineEnum returns true if field f declares its own enum values.
+func IsInlineEnum(f schema.Field) bool {
+	return f.Enum != nil && f.Type.Ref.Value == "" && f.Type.Type == schema.String
+}
+
+// EnumName returns the type name of the inline enum declared by field f of owner.
+func EnumName(owner string, f schema.Field) string {
+	return owner + "_" + f.Name
+}
+
+// Enums returns the named and inline enum types of s, sorted by name.
+func Enums(s *schema.Schema) (v []schema.Type) {
+	inline := func(owner string, fields []schema.Field) {
+		for _, f := range fields {
+			if IsInlineEnum(f) {
+				v = append(v, schema.Type{
+					Name:        EnumName(owner, f),
+					Description: "is " + f.Description,
+					Enum:        f.Enum,
+				})
+			}
+		}
+	}
+
+	for _, t := range s.TypesSlice() {
+		if t.IsEnum() {
+			v = append(v, t)
+			continue
+		}
+		if t.InputOf != "" {
+			continue
+		}
+		inline(t.Name, t.Properties)
+	}
+
+	for _, m := range s.Methods {
+		inline(m.Name+"_input", m.Inputs)
+		inline(m.Name+"_output", m.Outputs)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Name < v[j].Name
+	})
+
+	return
+}
+
 // FormatExtra .
 func FormatExtra(f schema.Field) string {
-	return FormatAttributes(f) + FormatEnum(f)
+	return FormatAttributes(f) + FormatConstraints(f) + FormatEnum(f) + FormatDefault(f)
+}
+
+// FormatDefault returns a formatted default value description.
+func FormatDefault(f schema.Field) string {
+	if f.Default == nil {
+		return ""
+	}
+
+	b, _ := json.Marshal(f.Default)
+	return " Defaults to " + string(b) + "."
+}
+
+// FormatConstraints returns a formatted description of the field constraints.
+func FormatConstraints(f schema.Field) string {
+	var s string
+	for _, c := range Checks(f) {
+		s += " Must " + strings.TrimPrefix(c.Message, "must ") + "."
+	}
+	return s
 }
 
 // FormatEnum returns a formatted enum description.
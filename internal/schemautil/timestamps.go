@@ -0,0 +1,69 @@
+package schemautil
+
+import (
+	"github.com/newlix/rpc/schema"
+)
+
+// HasTimestamps returns true if fields contain timestamps, directly or within
+// arrays, maps and the types they reference, which are not mapped to a type
+// of language lang. Mapped fields are left as-is.
+func HasTimestamps(s *schema.Schema, lang string, fields []schema.Field) bool {
+	return hasTimestamps(s, lang, fields, map[string]bool{})
+}
+
+// TypeHasTimestamps returns true if the properties of type t, or of the
+// variants of union t, contain timestamps as in HasTimestamps.
+func TypeHasTimestamps(s *schema.Schema, lang string, t schema.Type) bool {
+	return typeHasTimestamps(s, lang, t, map[string]bool{})
+}
+
+// hasTimestamps implementation, skipping types already seen.
+func hasTimestamps(s *schema.Schema, lang string, fields []schema.Field, seen map[string]bool) bool {
+	for _, f := range fields {
+		if fieldHasTimestamps(s, lang, f, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldHasTimestamps returns true if the value of field f contains timestamps.
+func fieldHasTimestamps(s *schema.Schema, lang string, f schema.Field, seen map[string]bool) bool {
+	if _, ok := Mapping(s, lang, f); ok {
+		return false
+	}
+
+	if ref := f.Type.Ref; ref.Value != "" {
+		return typeHasTimestamps(s, lang, ResolveRef(s, ref), seen)
+	}
+
+	switch f.Type.Type {
+	case schema.Timestamp:
+		return true
+	case schema.Array:
+		return fieldHasTimestamps(s, lang, f.Items.Field(), seen)
+	case schema.Object:
+		return f.HasValues() && fieldHasTimestamps(s, lang, f.Values.Field(), seen)
+	default:
+		return false
+	}
+}
+
+// typeHasTimestamps implementation, skipping types already seen.
+func typeHasTimestamps(s *schema.Schema, lang string, t schema.Type, seen map[string]bool) bool {
+	if _, ok := TypeMapping(lang, t); ok || seen[t.Name] {
+		return false
+	}
+	seen[t.Name] = true
+
+	if t.IsUnion() {
+		for _, v := range Variants(s, t) {
+			if typeHasTimestamps(s, lang, v, seen) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return hasTimestamps(s, lang, t.Properties, seen)
+}
@@ -0,0 +1,49 @@
+package schemautil_test
+
+import (
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test timestamps of fields and types.
+func TestHasTimestamps(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	var names []string
+	for _, v := range s.TypesSlice() {
+		if schemautil.TypeHasTimestamps(s, schemautil.TS, v) {
+			names = append(names, v.Name)
+		}
+	}
+	assert.Equal(t, []string{"item", "reminder", "time_reminder"}, names)
+
+	timestamp := schema.Field{Name: "at", Type: schema.TypeObject{Type: schema.Timestamp}}
+	assert.True(t, schemautil.HasTimestamps(s, schemautil.TS, []schema.Field{timestamp}))
+
+	fields := []schema.Field{
+		{Name: "items", Type: schema.TypeObject{Type: schema.Array}, Items: schema.ItemsObject{Ref: schema.Ref{Value: "#/types/item"}}},
+	}
+	assert.True(t, schemautil.HasTimestamps(s, schemautil.TS, fields))
+
+	fields = []schema.Field{
+		{Name: "times", Type: schema.TypeObject{Type: schema.Object}, Values: schema.ItemsObject{Type: schema.Array, Items: &schema.ItemsObject{Type: schema.Timestamp}}},
+	}
+	assert.True(t, schemautil.HasTimestamps(s, schemautil.TS, fields))
+
+	fields = []schema.Field{
+		{Name: "stats", Type: schema.TypeObject{Ref: schema.Ref{Value: "#/types/stats"}}},
+		{Name: "labels", Type: schema.TypeObject{Type: schema.Object}, Values: schema.ItemsObject{Type: schema.String}},
+	}
+	assert.False(t, schemautil.HasTimestamps(s, schemautil.TS, fields))
+
+	t.Run("mapped", func(t *testing.T) {
+		timestamp.Mappings = schema.Mappings{TS: &schema.Mapping{Type: "string"}}
+		assert.False(t, schemautil.HasTimestamps(s, schemautil.TS, []schema.Field{timestamp}))
+		assert.True(t, schemautil.HasTimestamps(s, schemautil.Swift, []schema.Field{timestamp}))
+	})
+}
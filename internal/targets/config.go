@@ -0,0 +1,327 @@
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/newlix/rpc/generators/goserver"
+	"github.com/newlix/rpc/generators/tmpl"
+	"github.com/newlix/rpc/generators/tstypes"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Config is the configuration of rpc-gen, for example:
+//
+//	schema: schema.json
+//	targets:
+//	  - language: go-types
+//	    output: api/types.go
+//	    package: api
+//	  - language: ts-client
+//	    output: web/client.ts
+//	    options:
+//	      fetch-library: cross-fetch
+//
+// Paths are relative to the configuration file.
+type Config struct {
+	// Schema is the path of the schema file, defaulting to schema.json.
+	Schema string `yaml:"schema"`
+
+	// Targets are the files generated.
+	Targets []Target `yaml:"targets"`
+}
+
+// Target is a file generated by a language generator.
+type Target struct {
+	// Language is the generator, such as go-types or ts-client, or the
+	// plugin executable rpc-gen-<language>.
+	Language string `yaml:"language"`
+
+	// Output is the path of the generated file, or the directory of the
+	// files generated by plugins.
+	Output string `yaml:"output"`
+
+	// Package is the package name, defaulting to that of the generator.
+	Package string `yaml:"package"`
+
+	// Options are the generator options, named after the flags of its
+	// command, such as types or fetch-library.
+	Options map[string]string `yaml:"options"`
+}
+
+// File is a generated file.
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// LoadConfig returns the configuration at path, with its paths made relative
+// to the working directory.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	err = dec.Decode(&c)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if c.Schema == "" {
+		c.Schema = "schema.json"
+	}
+
+	dir := filepath.Dir(path)
+	c.Schema = filepath.Join(dir, c.Schema)
+
+	for i, t := range c.Targets {
+		if t.Output == "" {
+			return nil, fmt.Errorf("%s: target %d: output is required", path, i+1)
+		}
+		c.Targets[i].Output = filepath.Join(dir, t.Output)
+
+		// path options of the built-in languages
+		for _, k := range []string{"query", "migrate", "template"} {
+			_, ok := languages[t.Language].options[k]
+			if v := t.Options[k]; ok && v != "" {
+				t.Options[k] = filepath.Join(dir, v)
+			}
+		}
+	}
+
+	return &c, nil
+}
+
+// language is a language generator.
+type language struct {
+	// pkg is the default package name.
+	pkg string
+
+	// options are the supported options and their defaults.
+	options map[string]string
+
+	// generate writes the file of target t to w, returning any other files.
+	generate func(w io.Writer, s *schema.Schema, t Target) ([]File, error)
+}
+
+// languages supported, keyed by name.
+var languages = map[string]language{
+	"go-types": {
+		pkg:     "api",
+		options: map[string]string{"validate": "true", "visibility": "all", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, GoTypes(w, s, t.Package, t.Options["validate"] == "true")
+		},
+	},
+	"go-client": {
+		pkg:     "client",
+		options: map[string]string{"visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, GoClient(w, s, t.Package)
+		},
+	},
+	"go-server": {
+		pkg:     "server",
+		options: map[string]string{"types": "", "readonly": goserver.Ignore, "embed": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, GoServer(w, s, t.Package, t.Options["types"], t.Options["readonly"], t.Options["embed"])
+		},
+	},
+	"ts-types": {
+		options: map[string]string{"js": "false", "visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return generateTS(w, t, func(w io.Writer, mode tstypes.Mode) error {
+				return TSTypes(w, s, mode)
+			})
+		},
+	},
+	"ts-client": {
+		options: map[string]string{"fetch-library": "", "types": "", "js": "false", "visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return generateTS(w, t, func(w io.Writer, mode tstypes.Mode) error {
+				return TSClient(w, s, t.Options["fetch-library"], t.Options["types"], mode)
+			})
+		},
+	},
+	"swift-types": {
+		options: map[string]string{"validate": "true", "visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, SwiftTypes(w, s, t.Options["validate"] == "true")
+		},
+	},
+	"swift-client": {
+		options: map[string]string{"client": "Client", "visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, SwiftClient(w, s, t.Options["client"])
+		},
+	},
+	"kotlin-types": {
+		pkg:     "com.example.rpc",
+		options: map[string]string{"validate": "true", "visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, KotlinTypes(w, s, t.Package, t.Options["validate"] == "true")
+		},
+	},
+	"kotlin-client": {
+		pkg:     "com.example",
+		options: map[string]string{"visibility": "public", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			return nil, KotlinClient(w, s, t.Package)
+		},
+	},
+	"template": {
+		options: map[string]string{"template": "", "visibility": "all", "groups": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) ([]File, error) {
+			if t.Options["template"] == "" {
+				return nil, fmt.Errorf("template: option \"template\" is required")
+			}
+			return nil, tmpl.Generate(w, s, t.Options["template"])
+		},
+	},
+	"sqlc": {
+		pkg:     "model",
+		options: map[string]string{"query": "", "migrate": ""},
+		generate: func(w io.Writer, s *schema.Schema, t Target) (files []File, err error) {
+			err = SQLCSchema(w, s)
+			if err != nil {
+				return nil, err
+			}
+
+			if path := t.Options["query"]; path != "" {
+				var b bytes.Buffer
+				err = SQLCQuery(&b, s, t.Package)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, File{Path: path, Content: b.Bytes()})
+			}
+
+			if path := t.Options["migrate"]; path != "" {
+				var b bytes.Buffer
+				err = SQLCMigrate(&b, s)
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, File{Path: path, Content: b.Bytes()})
+			}
+
+			return files, nil
+		},
+	},
+}
+
+// generateTS writes the TypeScript of target t with generate to w, or the
+// JavaScript along with its declarations in a .d.ts file when the js option is set.
+func generateTS(w io.Writer, t Target, generate func(io.Writer, tstypes.Mode) error) ([]File, error) {
+	if t.Options["js"] != "true" {
+		return nil, generate(w, tstypes.TS)
+	}
+
+	err := generate(w, tstypes.JS)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	err = generate(&b, tstypes.Declarations)
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.TrimSuffix(t.Output, filepath.Ext(t.Output)) + ".d.ts"
+	return []File{{Path: path, Content: b.Bytes()}}, nil
+}
+
+// Languages returns the names of the supported languages, sorted.
+func Languages() (v []string) {
+	for name := range languages {
+		v = append(v, name)
+	}
+	sort.Strings(v)
+	return
+}
+
+// Generate returns the files of target t generated from s, which is not
+// modified, so it may be shared by targets. Languages which are not built-in
+// are generated by plugins.
+func Generate(s *schema.Schema, t Target) ([]File, error) {
+	l, ok := languages[t.Language]
+	if !ok {
+		return generatePlugin(s, t)
+	}
+
+	// options & their defaults
+	options := map[string]string{}
+	for k, v := range l.options {
+		options[k] = v
+	}
+
+	for k, v := range t.Options {
+		if _, ok := l.options[k]; !ok {
+			return nil, fmt.Errorf("%s: unsupported option %q", t.Language, k)
+		}
+		options[k] = v
+	}
+
+	for _, k := range []string{"validate", "js"} {
+		v, ok := options[k]
+		if !ok {
+			continue
+		}
+
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: option %q must be true or false", t.Language, k)
+		}
+		options[k] = strconv.FormatBool(b)
+	}
+
+	t.Options = options
+	if t.Package == "" {
+		t.Package = l.pkg
+	}
+
+	s, err := Filter(s, options["visibility"], options["groups"])
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	files, err := l.generate(&b, s, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]File{{Path: t.Output, Content: b.Bytes()}}, files...), nil
+}
+
+// Filter returns s with only the methods of the given visibility and of the
+// comma-separated groups, or all of them when empty.
+func Filter(s *schema.Schema, visibility, groups string) (*schema.Schema, error) {
+	if visibility != "" {
+		var err error
+		s, err = schemautil.Filter(s, visibility)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if groups != "" {
+		return schemautil.FilterGroups(s, strings.Split(groups, ","))
+	}
+
+	return s, nil
+}
@@ -0,0 +1,164 @@
+package targets_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/targets"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test loading configurations.
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rpc.yaml")
+
+	t.Run("with paths relative to the config", func(t *testing.T) {
+		err := os.WriteFile(path, []byte("targets:\n  - language: sqlc\n    output: sql/schema.sql\n    options:\n      query: model/query.go\n"), 0644)
+		assert.NoError(t, err)
+
+		c, err := targets.LoadConfig(path)
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "schema.json"), c.Schema)
+		assert.Equal(t, filepath.Join(dir, "sql/schema.sql"), c.Targets[0].Output)
+		assert.Equal(t, filepath.Join(dir, "model/query.go"), c.Targets[0].Options["query"])
+	})
+
+	t.Run("without output", func(t *testing.T) {
+		err := os.WriteFile(path, []byte("targets:\n  - language: go-types\n"), 0644)
+		assert.NoError(t, err)
+
+		_, err = targets.LoadConfig(path)
+		assert.EqualError(t, err, path+": target 1: output is required")
+	})
+
+	t.Run("with an unknown property", func(t *testing.T) {
+		err := os.WriteFile(path, []byte("targets:\n  - language: go-types\n    ouput: api/types.go\n"), 0644)
+		assert.NoError(t, err)
+
+		_, err = targets.LoadConfig(path)
+		assert.Error(t, err)
+	})
+}
+
+// Test generating targets.
+func TestGenerate(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	t.Run("with options", func(t *testing.T) {
+		files, err := targets.Generate(s, targets.Target{
+			Language: "go-types",
+			Output:   "api/types.go",
+			Options:  map[string]string{"validate": "false"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.Equal(t, "api/types.go", files[0].Path)
+		assert.Contains(t, string(files[0].Content), "package api\n")
+		assert.NotContains(t, string(files[0].Content), "Validate()")
+	})
+
+	t.Run("without modifying the schema", func(t *testing.T) {
+		tags := s.Go.Tags
+		_, err := targets.Generate(s, targets.Target{Language: "go-client", Output: "client.go", Package: "todo"})
+		assert.NoError(t, err)
+		assert.Equal(t, tags, s.Go.Tags)
+	})
+
+	t.Run("with enums", func(t *testing.T) {
+		files, err := targets.Generate(s, targets.Target{Language: "go-types", Output: "types.go", Package: "todo"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(files[0].Content), "func (v *Priority) UnmarshalJSON(")
+
+		// clients keep unknown values of newer servers
+		files, err = targets.Generate(s, targets.Target{Language: "go-client", Output: "client.go", Package: "todo"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(files[0].Content), "func (v Priority) Valid() bool")
+		assert.NotContains(t, string(files[0].Content), "func (v *Priority) UnmarshalJSON(")
+	})
+
+	t.Run("with additional files", func(t *testing.T) {
+		files, err := targets.Generate(s, targets.Target{
+			Language: "sqlc",
+			Output:   "schema.sql",
+			Options:  map[string]string{"query": "query.go"},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, files, 2)
+		assert.Equal(t, "query.go", files[1].Path)
+	})
+
+	t.Run("with an unsupported language", func(t *testing.T) {
+		_, err := targets.Generate(s, targets.Target{Language: "cobol", Output: "client.cbl"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported language "cobol"`)
+	})
+
+	t.Run("with an unsupported option", func(t *testing.T) {
+		_, err := targets.Generate(s, targets.Target{Language: "go-types", Output: "types.go", Options: map[string]string{"fetch-library": "x"}})
+		assert.EqualError(t, err, `go-types: unsupported option "fetch-library"`)
+	})
+}
+
+// Test writing files.
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api", "types.go")
+
+	err := targets.WriteFile(path, []byte("package api\n"))
+	assert.NoError(t, err)
+
+	err = targets.WriteFile(path, []byte("package client\n"))
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "package client\n", string(b))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+// Test generating templates.
+func TestGenerate_template(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	t.Run("with a template", func(t *testing.T) {
+		files, err := targets.Generate(s, targets.Target{
+			Language: "template",
+			Output:   "docs/methods.md",
+			Options:  map[string]string{"template": "../../generators/tmpl/testdata/methods.md.tmpl", "visibility": "public"},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, string(files[0].Content), "## AddItem\n")
+		assert.NotContains(t, string(files[0].Content), "## GetStats\n")
+	})
+
+	t.Run("without a template", func(t *testing.T) {
+		_, err := targets.Generate(s, targets.Target{Language: "template", Output: "docs/methods.md"})
+		assert.EqualError(t, err, `template: option "template" is required`)
+	})
+}
+
+// Test generating JavaScript with declarations.
+func TestGenerate_js(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	files, err := targets.Generate(s, targets.Target{
+		Language: "ts-client",
+		Output:   "web/client.js",
+		Options:  map[string]string{"js": "true", "types": "./types"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, "web/client.js", files[0].Path)
+	assert.Contains(t, string(files[0].Content), "export class Client {\n")
+	assert.Equal(t, "web/client.d.ts", files[1].Path)
+	assert.Contains(t, string(files[1].Content), "export declare class Client {\n")
+}
@@ -0,0 +1,73 @@
+package targets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/newlix/rpc/plugin"
+	"github.com/newlix/rpc/schema"
+)
+
+// generatePlugin returns the files of target t generated from s by the
+// plugin executable rpc-gen-<language>, relative to the output directory.
+func generatePlugin(s *schema.Schema, t Target) ([]File, error) {
+	name := "rpc-gen-" + t.Language
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported language %q, must be one of: %s, or a plugin %s in PATH", t.Language, strings.Join(Languages(), ", "), name)
+	}
+
+	s, err = Filter(s, t.Options["visibility"], t.Options["groups"])
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := json.Marshal(plugin.Request{
+		Schema:  s,
+		Output:  t.Output,
+		Package: t.Package,
+		Options: t.Options,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: marshaling request: %w", name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = cmd.Run()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	var res []plugin.File
+	err = json.Unmarshal(stdout.Bytes(), &res)
+	if err != nil {
+		return nil, fmt.Errorf("%s: decoding response: %w", name, err)
+	}
+
+	var files []File
+	for _, f := range res {
+		// files must stay within the output directory
+		if !filepath.IsLocal(f.Path) {
+			return nil, fmt.Errorf("%s: file path %q must be relative to the output directory", name, f.Path)
+		}
+
+		files = append(files, File{
+			Path:    filepath.Join(t.Output, f.Path),
+			Content: []byte(f.Content),
+		})
+	}
+
+	return files, nil
+}
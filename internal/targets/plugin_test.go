@@ -0,0 +1,54 @@
+package targets_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/internal/targets"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test generating targets with plugins.
+func TestGenerate_plugin(t *testing.T) {
+	s, err := schema.Load("../../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	dir := t.TempDir()
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	plugin := func(name, script string) {
+		err := os.WriteFile(filepath.Join(dir, "rpc-gen-"+name), []byte("#!/bin/sh\n"+script), 0755)
+		assert.NoError(t, err)
+	}
+
+	t.Run("with files", func(t *testing.T) {
+		plugin("docs", `grep -q '"output":"docs"' && printf '%s' '[{"path":"api/methods.md","content":"# Methods\n"}]'`)
+
+		files, err := targets.Generate(s, targets.Target{Language: "docs", Output: "docs"})
+		assert.NoError(t, err)
+		assert.Equal(t, []targets.File{{Path: "docs/api/methods.md", Content: []byte("# Methods\n")}}, files)
+	})
+
+	t.Run("with an error", func(t *testing.T) {
+		plugin("broken", `echo 'unsupported schema' >&2; exit 1`)
+
+		_, err := targets.Generate(s, targets.Target{Language: "broken", Output: "docs"})
+		assert.EqualError(t, err, "rpc-gen-broken: exit status 1: unsupported schema")
+	})
+
+	t.Run("with a file outside the output", func(t *testing.T) {
+		plugin("escape", `echo '[{"path":"../main.go","content":""}]'`)
+
+		_, err := targets.Generate(s, targets.Target{Language: "escape", Output: "docs"})
+		assert.EqualError(t, err, `rpc-gen-escape: file path "../main.go" must be relative to the output directory`)
+	})
+
+	t.Run("without a plugin", func(t *testing.T) {
+		_, err := targets.Generate(s, targets.Target{Language: "cobol", Output: "docs"})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "or a plugin rpc-gen-cobol in PATH")
+	})
+}
@@ -0,0 +1,253 @@
+// Package targets generates the files of the clients, servers and types of a
+// schema, shared by the rpc-* commands and rpc-gen.
+package targets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/newlix/rpc/generators/goclient"
+	"github.com/newlix/rpc/generators/goserver"
+	"github.com/newlix/rpc/generators/gotypes"
+	"github.com/newlix/rpc/generators/kotlinclient"
+	"github.com/newlix/rpc/generators/kotlintypes"
+	"github.com/newlix/rpc/generators/sqlc"
+	"github.com/newlix/rpc/generators/swiftclient"
+	"github.com/newlix/rpc/generators/swifttypes"
+	"github.com/newlix/rpc/generators/tsclient"
+	"github.com/newlix/rpc/generators/tstypes"
+	"github.com/newlix/rpc/internal/gocode"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// GoTypes writes the Go types of package pkg to w.
+func GoTypes(w io.Writer, s *schema.Schema, pkg string, validate bool) error {
+	var body bytes.Buffer
+	err := gotypes.Generate(&body, s, validate)
+	if err != nil {
+		return fmt.Errorf("generating types: %w", err)
+	}
+
+	return writeGo(w, pkg, body.Bytes(), schemautil.Imports(s, schemautil.Go)...)
+}
+
+// GoClient writes the Go client of package pkg to w.
+func GoClient(w io.Writer, s *schema.Schema, pkg string) error {
+	// force tags to be json only, on a copy as s may be shared by targets
+	c := *s
+	c.Go.Tags = []string{"json"}
+	s = &c
+
+	var body bytes.Buffer
+	err := gotypes.Generate(&body, s, false)
+	if err != nil {
+		return fmt.Errorf("generating types: %w", err)
+	}
+
+	err = goclient.Generate(&body, s)
+	if err != nil {
+		return fmt.Errorf("generating client: %w", err)
+	}
+
+	return writeGo(w, pkg, body.Bytes(), schemautil.Imports(s, schemautil.Go)...)
+}
+
+// GoServer writes the Go server of package pkg to w, using the types of the
+// package imported from path types, and embedding the schema file embed.
+func GoServer(w io.Writer, s *schema.Schema, pkg, types, readonly, embed string) error {
+	paths := []string{"github.com/newlix/rpc"}
+	if len(types) > 0 {
+		paths = append(paths, types)
+		types = gocode.Name(types)
+	}
+
+	var body bytes.Buffer
+	err := goserver.Generate(&body, s, types, readonly, embed)
+	if err != nil {
+		return fmt.Errorf("generating server: %w", err)
+	}
+
+	return writeGo(w, pkg, body.Bytes(), paths...)
+}
+
+// TSTypes writes the TypeScript types of the given mode to w.
+func TSTypes(w io.Writer, s *schema.Schema, mode tstypes.Mode) error {
+	out := fmt.Fprintf
+
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+
+	err := tstypes.Generate(w, s, mode)
+	if err != nil {
+		return fmt.Errorf("generating types: %w", err)
+	}
+
+	return nil
+}
+
+// TSClient writes the TypeScript client of the given mode to w, importing
+// the types from the module types, or including them when empty.
+func TSClient(w io.Writer, s *schema.Schema, fetchLibrary, types string, mode tstypes.Mode) error {
+	out := fmt.Fprintf
+
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+
+	if types == "" {
+		err := tstypes.Generate(w, s, mode)
+		if err != nil {
+			return fmt.Errorf("generating types: %w", err)
+		}
+	}
+
+	err := tsclient.Generate(w, s, fetchLibrary, types, mode)
+	if err != nil {
+		return fmt.Errorf("generating client: %w", err)
+	}
+
+	return nil
+}
+
+// SwiftTypes writes the Swift types to w.
+func SwiftTypes(w io.Writer, s *schema.Schema, validate bool) error {
+	out := fmt.Fprintf
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+	err := swifttypes.Generate(w, s, validate)
+	if err != nil {
+		return fmt.Errorf("generating types: %w", err)
+	}
+
+	return nil
+}
+
+// SwiftClient writes the Swift client named client to w.
+func SwiftClient(w io.Writer, s *schema.Schema, client string) error {
+	out := fmt.Fprintf
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+
+	err := swiftclient.Generate(w, s, client)
+	if err != nil {
+		return fmt.Errorf("generating client: %w", err)
+	}
+
+	return nil
+}
+
+// KotlinTypes writes the Kotlin types of package pkg to w.
+func KotlinTypes(w io.Writer, s *schema.Schema, pkg string, validate bool) error {
+	out := fmt.Fprintf
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+	out(w, "package %s\n\n", pkg)
+
+	err := kotlintypes.Generate(w, s, validate)
+	if err != nil {
+		return fmt.Errorf("generating types: %w", err)
+	}
+
+	return nil
+}
+
+// KotlinClient writes the Kotlin client of package pkg to w.
+func KotlinClient(w io.Writer, s *schema.Schema, pkg string) error {
+	out := fmt.Fprintf
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+	out(w, "@file:Suppress(\"unused\")\n")
+	out(w, "package %s\n\n", pkg)
+	err := kotlinclient.Generate(w, s)
+	if err != nil {
+		return fmt.Errorf("generating client: %w", err)
+	}
+
+	return nil
+}
+
+// SQLCSchema writes the sqlc schema of the types to w.
+func SQLCSchema(w io.Writer, s *schema.Schema) error {
+	out := fmt.Fprintf
+
+	out(w, "-- Do not edit, this file was generated by github.com/newlix/rpc/cmd/rpc-sqlc.\n\n")
+
+	err := sqlc.GenerateSchema(w, s)
+	if err != nil {
+		return fmt.Errorf("generating sqlc schema: %w", err)
+	}
+
+	return nil
+}
+
+// SQLCQuery writes the CRUD queries of the types of package pkg to w.
+func SQLCQuery(w io.Writer, s *schema.Schema, pkg string) error {
+	out := fmt.Fprintf
+
+	out(w, "// Do not edit, this file was generated by github.com/newlix/rpc.\n\n")
+	out(w, "package %s\n\n", pkg)
+
+	out(w, "import (\n")
+	out(w, "  \"context\"\n")
+	out(w, ")\n\n")
+
+	err := sqlc.GenerateQuery(w, s)
+	if err != nil {
+		return fmt.Errorf("generating sqlc query: %w", err)
+	}
+
+	return nil
+}
+
+// SQLCMigrate writes the data migration of the types to w.
+func SQLCMigrate(w io.Writer, s *schema.Schema) error {
+	err := sqlc.GenerateMigrate(w, s)
+	if err != nil {
+		return fmt.Errorf("generating sqlc migration: %w", err)
+	}
+
+	return nil
+}
+
+// writeGo writes the formatted Go source of package pkg with body to w.
+func writeGo(w io.Writer, pkg string, body []byte, paths ...string) error {
+	b, err := gocode.Source(pkg, body, paths...)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// WriteFile writes the file at path atomically, renaming a temporary file
+// written next to it, and creating its directory if necessary.
+func WriteFile(path string, b []byte) error {
+	dir := filepath.Dir(path)
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(b)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(f.Name(), 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
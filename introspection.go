@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// PublicSchema is a schema without its private methods and types, and the
+// types referenced only by private methods, encoded once for serving.
+type PublicSchema struct {
+	schema  []byte
+	methods []byte
+}
+
+// methodListing is a method listed by WriteMethods.
+type methodListing struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Group       string `json:"group,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+}
+
+// NewPublicSchema returns the public schema of the schema file at path in
+// fsys, such as "schema.json" or "schema.yaml" in an embed.FS, which also
+// holds the files it includes or references.
+func NewPublicSchema(fsys fs.FS, path string) (*PublicSchema, error) {
+	s, err := schema.LoadFS(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("loading schema: %w", err)
+	}
+
+	s, err = schemautil.Filter(s, schemautil.Public)
+	if err != nil {
+		return nil, fmt.Errorf("filtering schema: %w", err)
+	}
+
+	var res struct {
+		Methods []methodListing `json:"methods"`
+	}
+	res.Methods = []methodListing{}
+
+	for _, m := range s.Methods {
+		res.Methods = append(res.Methods, methodListing{
+			Name:        m.Name,
+			Description: m.Description,
+			Group:       m.Group,
+			Deprecated:  m.Deprecated != nil,
+		})
+	}
+
+	var p PublicSchema
+
+	p.schema, err = encode(s)
+	if err != nil {
+		return nil, fmt.Errorf("encoding schema: %w", err)
+	}
+
+	p.methods, err = encode(res)
+	if err != nil {
+		return nil, fmt.Errorf("encoding methods: %w", err)
+	}
+
+	return &p, nil
+}
+
+// MustPublicSchema returns the public schema of the schema file at path in
+// fsys like NewPublicSchema, or panics. It is used to initialize package
+// variables.
+func MustPublicSchema(fsys fs.FS, path string) *PublicSchema {
+	p, err := NewPublicSchema(fsys, path)
+	if err != nil {
+		panic(fmt.Sprintf("rpc: %s: %s", path, err))
+	}
+	return p
+}
+
+// WriteSchema writes the public schema s as JSON.
+func WriteSchema(w http.ResponseWriter, s *PublicSchema) {
+	writeJSON(w, s.schema)
+}
+
+// WriteMethods writes the names and descriptions of the methods of the
+// public schema s.
+func WriteMethods(w http.ResponseWriter, s *PublicSchema) {
+	writeJSON(w, s.methods)
+}
+
+// encode returns v as indented JSON.
+func encode(v interface{}) ([]byte, error) {
+	b, err := stdjson.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// writeJSON writes the JSON document b as a response.
+func writeJSON(w http.ResponseWriter, b []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
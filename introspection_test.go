@@ -0,0 +1,133 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc"
+)
+
+// Test serving schemas.
+func TestWriteSchema(t *testing.T) {
+	t.Run("without private methods and types", func(t *testing.T) {
+		s, err := rpc.NewPublicSchema(os.DirFS("examples/todo"), "schema.json")
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		rpc.WriteSchema(w, s)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		var doc struct {
+			Version string
+			Methods []struct{ Name string }
+			Types   map[string]interface{}
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "1.0.0", doc.Version)
+
+		var names []string
+		for _, m := range doc.Methods {
+			names = append(names, m.Name)
+		}
+		assert.Equal(t, []string{"add_item", "get_items", "remove_item", "update_item"}, names)
+		assert.NotContains(t, doc.Types, "stats")
+		assert.Contains(t, doc.Types, "item")
+	})
+
+	t.Run("without types referenced only by private methods", func(t *testing.T) {
+		s, err := rpc.NewPublicSchema(file("schema.yaml", `
+name: todo
+version: 2.0.0
+methods:
+  - name: get_stats
+    description: returns statistics.
+    private: true
+    outputs:
+      - name: stats
+        description: the statistics.
+        type: { $ref: "#/types/stats" }
+types:
+  stats:
+    description: is the statistics.
+    properties:
+      - name: total
+        description: the number of items.
+        type: integer
+`), "schema.yaml")
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		rpc.WriteSchema(w, s)
+		assert.Equal(t, 200, w.Code)
+
+		var doc struct {
+			Version string
+			Methods []interface{}
+			Types   map[string]interface{}
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Equal(t, "2.0.0", doc.Version)
+		assert.Empty(t, doc.Methods)
+		assert.Empty(t, doc.Types)
+	})
+
+	t.Run("with included files", func(t *testing.T) {
+		s, err := rpc.NewPublicSchema(os.DirFS("schema/testdata/include"), "schema.json")
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		rpc.WriteSchema(w, s)
+		assert.Equal(t, 200, w.Code)
+
+		var doc struct {
+			Types map[string]interface{}
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+		assert.Contains(t, doc.Types, "money")
+	})
+
+	t.Run("with an invalid schema", func(t *testing.T) {
+		_, err := rpc.NewPublicSchema(file("schema.json", "{"), "schema.json")
+		assert.Error(t, err)
+
+		assert.Panics(t, func() {
+			rpc.MustPublicSchema(file("schema.json", "{"), "schema.json")
+		})
+	})
+}
+
+// Test listing methods.
+func TestWriteMethods(t *testing.T) {
+	s, err := rpc.NewPublicSchema(file("schema.json", `{
+		"name": "todo",
+		"version": "1.0.0",
+		"groups": [{ "name": "items", "summary": "Manage items.", "description": "Manages items." }],
+		"methods": [
+			{ "name": "add_item", "description": "adds an item.", "group": "items" },
+			{ "name": "get_stats", "description": "returns stats.", "private": true },
+			{ "name": "remove_item", "description": "removes an item.", "deprecated": true }
+		]
+	}`), "schema.json")
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	rpc.WriteMethods(w, s)
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{
+		"methods": [
+			{ "name": "add_item", "description": "adds an item.", "group": "items" },
+			{ "name": "remove_item", "description": "removes an item.", "deprecated": true }
+		]
+	}`, w.Body.String())
+}
+
+// file returns a file system with the file at path holding data.
+func file(path, data string) fstest.MapFS {
+	return fstest.MapFS{path: {Data: []byte(data)}}
+}
@@ -0,0 +1,84 @@
+package plugin
+
+import (
+	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+// Target languages of type mappings.
+const (
+	Go     = schemautil.Go
+	TS     = schemautil.TS
+	Swift  = schemautil.Swift
+	Kotlin = schemautil.Kotlin
+)
+
+// GoName returns a name formatted for Go, such as AddItem for add_item.
+func GoName(s string) string {
+	return format.GoName(s)
+}
+
+// JsName returns a name formatted for JS, such as addItem for add_item.
+func JsName(s string) string {
+	return format.JsName(s)
+}
+
+// ID returns the id case for anchors, such as add_item for addItem.
+func ID(s string) string {
+	return format.ID(s)
+}
+
+// ResolveRef returns the type referenced by ref, or panics.
+func ResolveRef(s *schema.Schema, ref schema.Ref) schema.Type {
+	return schemautil.ResolveRef(s, ref)
+}
+
+// Variants returns the resolved variant types of union t.
+func Variants(s *schema.Schema, t schema.Type) []schema.Type {
+	return schemautil.Variants(s, t)
+}
+
+// IsInlineEnum returns true if field f declares its own enum values.
+func IsInlineEnum(f schema.Field) bool {
+	return schemautil.IsInlineEnum(f)
+}
+
+// EnumName returns the type name of the inline enum declared by field f of owner.
+func EnumName(owner string, f schema.Field) string {
+	return schemautil.EnumName(owner, f)
+}
+
+// Enums returns the named and inline enum types of s, sorted by name.
+func Enums(s *schema.Schema) []schema.Type {
+	return schemautil.Enums(s)
+}
+
+// Groups returns the groups of s which have methods, in order.
+func Groups(s *schema.Schema) []schema.Group {
+	return schemautil.Groups(s)
+}
+
+// GroupMethods returns the methods of s in the named group, or the
+// ungrouped methods when name is empty.
+func GroupMethods(s *schema.Schema, name string) []schema.Method {
+	return schemautil.GroupMethods(s, name)
+}
+
+// Inputs returns a copy of s where method inputs reference variants of the
+// types with read-only fields, without them.
+func Inputs(s *schema.Schema) *schema.Schema {
+	return schemautil.Inputs(s)
+}
+
+// Mapping returns the type of language lang used in place of the generated
+// type of field f, if any.
+func Mapping(s *schema.Schema, lang string, f schema.Field) (schema.Mapping, bool) {
+	return schemautil.Mapping(s, lang, f)
+}
+
+// FormatExtra returns a description of the attributes, constraints, enum
+// values and default of field f, such as " Must be at most 200 characters long."
+func FormatExtra(f schema.Field) string {
+	return schemautil.FormatExtra(f)
+}
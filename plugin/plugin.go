@@ -0,0 +1,127 @@
+// Package plugin implements the protocol of rpc-gen plugins, executables
+// named rpc-gen-<language> which generate the files of a target language.
+//
+// rpc-gen writes a Request as JSON to the standard input of the plugin, and
+// reads back the generated files as a JSON array of objects with a path and
+// content from its standard output. Plugins exit with a non-zero status to
+// report errors written to their standard error. A plugin is as simple as:
+//
+//	func main() {
+//		plugin.Run(func(r *plugin.Request) ([]plugin.File, error) {
+//			var b strings.Builder
+//			for _, m := range r.Schema.Methods {
+//				fmt.Fprintf(&b, "- %s\n", plugin.GoName(m.Name))
+//			}
+//			return []plugin.File{{Path: "methods.md", Content: b.String()}}, nil
+//		})
+//	}
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// Request is the request of a plugin.
+type Request struct {
+	// Schema is the schema, filtered by the visibility and groups options.
+	Schema *schema.Schema
+
+	// Output is the directory of the generated files.
+	Output string
+
+	// Package is the package name of the target, if any.
+	Package string
+
+	// Options are the options of the target.
+	Options map[string]string
+}
+
+// request is the JSON representation of a Request.
+type request struct {
+	Schema  json.RawMessage   `json:"schema"`
+	Output  string            `json:"output"`
+	Package string            `json:"package,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// MarshalJSON implementation.
+func (r Request) MarshalJSON() ([]byte, error) {
+	s, err := json.Marshal(r.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling schema: %w", err)
+	}
+
+	return json.Marshal(request{
+		Schema:  s,
+		Output:  r.Output,
+		Package: r.Package,
+		Options: r.Options,
+	})
+}
+
+// UnmarshalJSON implementation. The schema is loaded as if by schema.Load.
+func (r *Request) UnmarshalJSON(b []byte) error {
+	var v request
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return err
+	}
+
+	s, err := schema.LoadBytes("schema.json", v.Schema)
+	if err != nil {
+		return fmt.Errorf("loading schema: %w", err)
+	}
+
+	*r = Request{
+		Schema:  s,
+		Output:  v.Output,
+		Package: v.Package,
+		Options: v.Options,
+	}
+
+	return nil
+}
+
+// File is a generated file.
+type File struct {
+	// Path is the path of the file, relative to the output directory.
+	Path string `json:"path"`
+
+	// Content is the content of the file.
+	Content string `json:"content"`
+}
+
+// Generate reads a request from r, and writes the files generated by fn to w.
+func Generate(r io.Reader, w io.Writer, fn func(*Request) ([]File, error)) error {
+	var req Request
+	err := json.NewDecoder(r).Decode(&req)
+	if err != nil {
+		return fmt.Errorf("decoding request: %w", err)
+	}
+
+	files, err := fn(&req)
+	if err != nil {
+		return err
+	}
+
+	if files == nil {
+		files = []File{}
+	}
+
+	return json.NewEncoder(w).Encode(files)
+}
+
+// Run generates the files of the request read from stdin with fn, exiting
+// with status 1 on error.
+func Run(fn func(*Request) ([]File, error)) {
+	err := Generate(os.Stdin, os.Stdout, fn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
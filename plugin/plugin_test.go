@@ -0,0 +1,69 @@
+package plugin_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/plugin"
+	"github.com/newlix/rpc/schema"
+)
+
+// Test generating the files of requests.
+func TestGenerate(t *testing.T) {
+	s, err := schema.Load("../examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	req, err := json.Marshal(plugin.Request{
+		Schema:  s,
+		Output:  "docs",
+		Options: map[string]string{"title": "Methods"},
+	})
+	assert.NoError(t, err, "marshaling")
+
+	t.Run("with files", func(t *testing.T) {
+		var w bytes.Buffer
+		err := plugin.Generate(bytes.NewReader(req), &w, func(r *plugin.Request) ([]plugin.File, error) {
+			assert.Equal(t, "docs", r.Output)
+			assert.Equal(t, s.Name, r.Schema.Name)
+			assert.Len(t, r.Schema.Types, len(s.Types))
+
+			var b strings.Builder
+			b.WriteString("# " + r.Options["title"] + "\n")
+			for _, m := range r.Schema.Methods {
+				b.WriteString("- " + plugin.GoName(m.Name) + "\n")
+			}
+			return []plugin.File{{Path: "methods.md", Content: b.String()}}, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `[{"path":"methods.md","content":"# Methods\n- AddItem\n- GetItems\n- GetStats\n- RemoveItem\n- UpdateItem\n"}]`+"\n", w.String())
+	})
+
+	t.Run("without files", func(t *testing.T) {
+		var w bytes.Buffer
+		err := plugin.Generate(bytes.NewReader(req), &w, func(r *plugin.Request) ([]plugin.File, error) {
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "[]\n", w.String())
+	})
+
+	t.Run("with an error", func(t *testing.T) {
+		err := plugin.Generate(bytes.NewReader(req), &bytes.Buffer{}, func(r *plugin.Request) ([]plugin.File, error) {
+			return nil, errors.New("boom")
+		})
+		assert.EqualError(t, err, "boom")
+	})
+
+	t.Run("with an invalid schema", func(t *testing.T) {
+		err := plugin.Generate(strings.NewReader(`{"schema":{"name":"todo"}}`), &bytes.Buffer{}, func(r *plugin.Request) ([]plugin.File, error) {
+			return nil, nil
+		})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "decoding request: loading schema: ")
+	})
+}
@@ -1,14 +1,24 @@
 package rpc
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	jsoniter "github.com/json-iterator/go"
 )
 
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
+// Validator is the interface used for validating input.
+type Validator interface {
+	Validate() error
+}
+
 // ReadRequest parses application/json request bodies into value, or returns an error.
+// When value is a Validator it is validated after decoding.
 func ReadRequest(r *http.Request, value interface{}) error {
 	switch r.Header.Get("Content-Type") {
 	case "application/json":
@@ -18,8 +28,86 @@ func ReadRequest(r *http.Request, value interface{}) error {
 			return BadRequest("Failed to parse malformed request body, must be a valid JSON object")
 		}
 
+		// validate
+		if v, ok := value.(Validator); ok {
+			err := v.Validate()
+			if err != nil {
+				return Invalid(err.Error())
+			}
+		}
+
 		return nil
 	default:
 		return BadRequest("Unsupported request Content-Type, must be application/json")
 	}
 }
+
+// ReadRequestRejecting parses request bodies like ReadRequest, but rejects those
+// setting any of the given read-only fields to a value other than null. Fields are
+// dot-separated paths, where "[]" matches array elements and "*" object values,
+// for example "items[].id".
+func ReadRequestRejecting(r *http.Request, value interface{}, fields ...string) error {
+	if r.Header.Get("Content-Type") != "application/json" {
+		return ReadRequest(r, value)
+	}
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return BadRequest("Failed to read request body")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(b))
+
+	var body interface{}
+	if err := json.Unmarshal(b, &body); err == nil {
+		for _, field := range fields {
+			if isSet(body, splitPath(field)) {
+				return Invalid(fmt.Sprintf("%s is read-only", field))
+			}
+		}
+	}
+
+	return ReadRequest(r, value)
+}
+
+// splitPath returns the segments of a field path.
+func splitPath(field string) (path []string) {
+	for _, s := range strings.Split(field, ".") {
+		name := strings.TrimRight(s, "[]")
+		path = append(path, name)
+		for i := 0; i < (len(s)-len(name))/2; i++ {
+			path = append(path, "[]")
+		}
+	}
+	return
+}
+
+// isSet returns true if v has a non-null value at path.
+func isSet(v interface{}, path []string) bool {
+	if len(path) == 0 {
+		return v != nil
+	}
+
+	switch p := path[0]; p {
+	case "[]":
+		a, _ := v.([]interface{})
+		for _, e := range a {
+			if isSet(e, path[1:]) {
+				return true
+			}
+		}
+	case "*":
+		o, _ := v.(map[string]interface{})
+		for _, e := range o {
+			if isSet(e, path[1:]) {
+				return true
+			}
+		}
+	default:
+		o, _ := v.(map[string]interface{})
+		if e, ok := o[p]; ok {
+			return isSet(e, path[1:])
+		}
+	}
+
+	return false
+}
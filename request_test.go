@@ -1,6 +1,7 @@
 package rpc_test
 
 import (
+	"errors"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -43,6 +44,56 @@ func TestReadRequest(t *testing.T) {
 		assert.NoError(t, err, "parsing")
 		assert.Equal(t, "Tobi", in.Name)
 	})
+
+	t.Run("with an invalid body", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", strings.NewReader(`{ "name": "" }`))
+		r.Header.Set("Content-Type", "application/json")
+		var in petInput
+		err := rpc.ReadRequest(r, &in)
+		assert.EqualError(t, err, `name must be at least 1 character long`)
+		assert.Equal(t, "invalid", err.(rpc.TypeProvider).Type())
+	})
+}
+
+// Test requests with read-only fields.
+func TestReadRequestRejecting(t *testing.T) {
+	t.Run("without read-only fields", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", strings.NewReader(`{ "pets": [{ "name": "Tobi", "id": null }] }`))
+		r.Header.Set("Content-Type", "application/json")
+		var in struct{ Pets []struct{ Name string } }
+		err := rpc.ReadRequestRejecting(r, &in, "pets[].id", "owner.id")
+		assert.NoError(t, err, "parsing")
+		assert.Equal(t, "Tobi", in.Pets[0].Name)
+	})
+
+	t.Run("with a read-only field", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", strings.NewReader(`{ "pets": [{ "name": "Tobi" }, { "name": "Loki", "id": 5 }] }`))
+		r.Header.Set("Content-Type", "application/json")
+		var in struct{ Pets []struct{ Name string } }
+		err := rpc.ReadRequestRejecting(r, &in, "pets[].id")
+		assert.EqualError(t, err, `pets[].id is read-only`)
+	})
+
+	t.Run("with a read-only field of object values", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/", strings.NewReader(`{ "pets": { "tobi": { "id": 5 } } }`))
+		r.Header.Set("Content-Type", "application/json")
+		var in struct{}
+		err := rpc.ReadRequestRejecting(r, &in, "pets.*.id")
+		assert.EqualError(t, err, `pets.*.id is read-only`)
+	})
+}
+
+// petInput is a validated input.
+type petInput struct {
+	Name string `json:"name"`
+}
+
+// Validate implementation.
+func (v petInput) Validate() error {
+	if v.Name == "" {
+		return errors.New("name must be at least 1 character long")
+	}
+	return nil
 }
 
 // Benchmark requests.
@@ -0,0 +1,223 @@
+// Package rpctest provides utilities for testing RPC servers against their schema.
+package rpctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// VerifyExamples replays the examples of every method in s against h as
+// subtests, failing those whose response differs from the example output.
+// Fields matching the ignore paths, such as "item.id" or "items[].created_at",
+// are not compared, nor are fields absent from the example output, such as
+// the zero values servers respond with.
+func VerifyExamples(t *testing.T, h http.Handler, s *schema.Schema, ignore ...string) {
+	t.Helper()
+
+	for _, m := range s.Methods {
+		for i, e := range m.Examples {
+			name := e.Name
+			if name == "" {
+				name = fmt.Sprintf("example %d", i+1)
+			}
+
+			t.Run(m.Name+"/"+name, func(t *testing.T) {
+				if err := Verify(h, m, e, ignore...); err != nil {
+					t.Error(err)
+				}
+			})
+		}
+	}
+}
+
+// Verify posts the input of example e to method m of h, and returns an error
+// if the response differs from the example output, ignoring the fields absent
+// from it. Paths are dot-separated, where "[]" matches array elements and "*"
+// object values.
+func Verify(h http.Handler, m schema.Method, e schema.MethodExample, ignore ...string) error {
+	input := e.Input
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+
+	b, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("marshaling input: %w", err)
+	}
+
+	r := httptest.NewRequest("POST", "/"+m.Name, bytes.NewReader(b))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code >= 300 {
+		var res struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || res.Type == "" {
+			return fmt.Errorf("responded with %d: %s", w.Code, strings.TrimSpace(w.Body.String()))
+		}
+
+		return fmt.Errorf("responded with %d %s error: %s", w.Code, res.Type, res.Message)
+	}
+
+	var output interface{} = map[string]interface{}{}
+	if w.Body.Len() > 0 {
+		if err := json.Unmarshal(w.Body.Bytes(), &output); err != nil {
+			return fmt.Errorf("decoding response: %w", err)
+		}
+	}
+
+	expected := e.Output
+	if expected == nil {
+		expected = map[string]interface{}{}
+	}
+
+	var patterns [][]string
+	for _, p := range ignore {
+		patterns = append(patterns, split(p))
+	}
+
+	return compare(nil, expected, output, patterns)
+}
+
+// compare returns an error describing the first difference of actual from
+// expected, skipping the values at paths matching the ignore patterns and the
+// object properties expected does not declare.
+func compare(path []string, expected, actual interface{}, ignore [][]string) error {
+	if ignored(path, ignore) {
+		return nil
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		for _, k := range keys(e) {
+			p := append(append([]string{}, path...), k)
+			av, ok := a[k]
+
+			switch {
+			case !ok && !ignored(p, ignore):
+				return fmt.Errorf("%s: expected %s, got nothing", format(p), show(e[k]))
+			case ok:
+				if err := compare(p, e[k], av, ignore); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+
+		if len(a) != len(e) {
+			return fmt.Errorf("%s: expected %d elements, got %d", format(path), len(e), len(a))
+		}
+
+		for i := range e {
+			p := append(append([]string{}, path...), fmt.Sprintf("[%d]", i))
+			if err := compare(p, e[i], a[i], ignore); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		return fmt.Errorf("%s: expected %s, got %s", format(path), show(expected), show(actual))
+	}
+
+	return nil
+}
+
+// ignored returns true if path matches any of the ignore patterns.
+func ignored(path []string, ignore [][]string) bool {
+	for _, p := range ignore {
+		if matches(p, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the pattern matches path, where "[]" matches any
+// array index and "*" any object key.
+func matches(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+
+	for i, p := range pattern {
+		isIndex := strings.HasPrefix(path[i], "[")
+		switch {
+		case p == "[]" && isIndex:
+		case p == "*" && !isIndex:
+		case p != path[i]:
+			return false
+		}
+	}
+
+	return true
+}
+
+// split returns the segments of an ignore path.
+func split(path string) (v []string) {
+	for _, s := range strings.Split(path, ".") {
+		name := strings.TrimRight(s, "[]")
+		if name != "" {
+			v = append(v, name)
+		}
+		for i := 0; i < (len(s)-len(name))/2; i++ {
+			v = append(v, "[]")
+		}
+	}
+	return
+}
+
+// format returns the path segments as a string such as "items[0].id".
+func format(path []string) string {
+	if len(path) == 0 {
+		return "output"
+	}
+
+	var b strings.Builder
+	for i, s := range path {
+		if i > 0 && !strings.HasPrefix(s, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(s)
+	}
+	return b.String()
+}
+
+// keys returns the sorted keys of m.
+func keys(m map[string]interface{}) (v []string) {
+	for k := range m {
+		v = append(v, k)
+	}
+	sort.Strings(v)
+	return
+}
+
+// show returns the JSON representation of v.
+func show(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
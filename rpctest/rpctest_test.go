@@ -0,0 +1,113 @@
+package rpctest_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc"
+	"github.com/newlix/rpc/rpctest"
+	"github.com/newlix/rpc/schema"
+)
+
+// user model.
+type user struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+// addUserOutput params.
+type addUserOutput struct {
+	User user `json:"user"`
+}
+
+// server is a users server assigning ids from next.
+type server struct {
+	next int
+}
+
+// ServeHTTP implementation.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/add_user":
+		var in struct {
+			Name string `json:"name"`
+		}
+
+		if err := rpc.ReadRequest(r, &in); err != nil {
+			rpc.WriteError(w, err)
+			return
+		}
+
+		s.next++
+		rpc.WriteResponse(w, addUserOutput{
+			User: user{
+				ID:        s.next,
+				Name:      in.Name,
+				CreatedAt: time.Now().UTC().Format(time.RFC3339),
+			},
+		})
+	case "/remove_users":
+		rpc.WriteResponse(w, nil)
+	default:
+		rpc.WriteError(w, rpc.BadRequest("Invalid method"))
+	}
+}
+
+func TestVerifyExamples(t *testing.T) {
+	s, err := schema.Load("testdata/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	rpctest.VerifyExamples(t, &server{}, s, "user.created_at")
+}
+
+func TestVerify(t *testing.T) {
+	s, err := schema.Load("testdata/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	m := s.Methods[0]
+	e := m.Examples[0]
+
+	t.Run("with ignored paths", func(t *testing.T) {
+		err := rpctest.Verify(&server{next: 5}, m, e, "user.id", "user.created_at")
+		assert.NoError(t, err)
+	})
+
+	t.Run("with a different value", func(t *testing.T) {
+		err := rpctest.Verify(&server{next: 5}, m, e, "user.created_at")
+		assert.EqualError(t, err, `user.id: expected 1, got 6`)
+	})
+
+	t.Run("with a missing field", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{ "user": { "id": 1, "name": "Tobi" } }`)
+		})
+
+		err := rpctest.Verify(h, m, e)
+		assert.EqualError(t, err, `user.created_at: expected "2026-10-19T09:00:00Z", got nothing`)
+	})
+
+	t.Run("with fields absent from the example", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{ "user": { "id": 1, "name": "Tobi", "created_at": "2026-10-19T09:00:00Z", "email": "" }, "admin": false }`)
+		})
+
+		err := rpctest.Verify(h, m, e)
+		assert.NoError(t, err)
+	})
+
+	t.Run("with an error response", func(t *testing.T) {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rpc.WriteError(w, rpc.BadRequest("Invalid method"))
+		})
+
+		err := rpctest.Verify(h, m, e)
+		assert.EqualError(t, err, `responded with 400 bad_request error: Invalid method`)
+	})
+}
@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Builder builds schemas programmatically, for example:
+//
+//	s, err := schema.New("todo").
+//		Version("1.0.0").
+//		Type(schema.Type{Name: "item", Description: "a to-do item.", Properties: ...}).
+//		Method(schema.Method{Name: "add_item", Description: "adds an item."}).
+//		Input(schema.Field{Name: "item", Type: schema.TypeObject{Ref: schema.Ref{Value: "#/types/item"}}}).
+//		Build()
+//
+// Inputs, outputs and examples are added to the last method declared.
+type Builder struct {
+	schema Schema
+	err    error
+}
+
+// New returns a builder of the schema named name.
+func New(name string) *Builder {
+	return &Builder{
+		schema: Schema{
+			Name:  name,
+			Types: map[string]Type{},
+		},
+	}
+}
+
+// Version sets the version of the schema.
+func (b *Builder) Version(v string) *Builder {
+	b.schema.Version = v
+	return b
+}
+
+// Description sets the description of the schema.
+func (b *Builder) Description(s string) *Builder {
+	b.schema.Description = s
+	return b
+}
+
+// Group adds group g.
+func (b *Builder) Group(g Group) *Builder {
+	b.schema.Groups = append(b.schema.Groups, g)
+	return b
+}
+
+// Type adds type t, keyed by its name.
+func (b *Builder) Type(t Type) *Builder {
+	if _, ok := b.schema.Types[t.Name]; ok {
+		b.fail(fmt.Errorf("type %q already declared", t.Name))
+	}
+	b.schema.Types[t.Name] = t
+	return b
+}
+
+// Method adds method m.
+func (b *Builder) Method(m Method) *Builder {
+	for _, prev := range b.schema.Methods {
+		if prev.Name == m.Name {
+			b.fail(fmt.Errorf("method %q already declared", m.Name))
+		}
+	}
+	b.schema.Methods = append(b.schema.Methods, m)
+	return b
+}
+
+// Input adds input fields to the last method.
+func (b *Builder) Input(fields ...Field) *Builder {
+	if m := b.method("input"); m != nil {
+		m.Inputs = append(m.Inputs, fields...)
+	}
+	return b
+}
+
+// Output adds output fields to the last method.
+func (b *Builder) Output(fields ...Field) *Builder {
+	if m := b.method("output"); m != nil {
+		m.Outputs = append(m.Outputs, fields...)
+	}
+	return b
+}
+
+// Example adds example e to the last method.
+func (b *Builder) Example(e MethodExample) *Builder {
+	if m := b.method("example"); m != nil {
+		m.Examples = append(m.Examples, e)
+	}
+	return b
+}
+
+// Build returns the schema, validated as if loaded by Load.
+func (b *Builder) Build() (*Schema, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	doc, err := json.Marshal(&b.schema)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling: %w", err)
+	}
+
+	return LoadBytes(b.schema.Name+".json", doc)
+}
+
+// method returns the last method, or nil if none was declared before the
+// thing added.
+func (b *Builder) method(thing string) *Method {
+	if len(b.schema.Methods) == 0 {
+		b.fail(fmt.Errorf("%s added before any method", thing))
+		return nil
+	}
+	return &b.schema.Methods[len(b.schema.Methods)-1]
+}
+
+// fail records the first error of the builder.
+func (b *Builder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
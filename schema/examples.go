@@ -0,0 +1,208 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// validateExamples checks the examples of methods and types match their
+// fields: required fields, kinds, enums and references.
+func (s *Schema) validateExamples() error {
+	for _, m := range s.Methods {
+		for i, e := range m.Examples {
+			name := exampleName(i, e.Name)
+
+			if err := s.checkObject("", m.Inputs, e.Input, ""); err != nil {
+				return fmt.Errorf("%s: method %q: %s: input: %w", m.File, m.Name, name, err)
+			}
+
+			if err := s.checkObject("", m.Outputs, e.Output, ""); err != nil {
+				return fmt.Errorf("%s: method %q: %s: output: %w", m.File, m.Name, name, err)
+			}
+		}
+	}
+
+	for _, t := range s.TypesSlice() {
+		for i, e := range t.Examples {
+			if err := s.checkType("", t, e.Value); err != nil {
+				return fmt.Errorf("%s: type %q: %s: %w", t.File, t.Name, exampleName(i, ""), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// exampleName returns the name used in errors for the example at index i.
+func exampleName(i int, name string) string {
+	if name != "" {
+		return fmt.Sprintf("example %q", name)
+	}
+	return fmt.Sprintf("example %d", i+1)
+}
+
+// checkObject checks the object v at path matches fields. The discriminator
+// property of a union variant is allowed in addition to the fields.
+func (s *Schema) checkObject(path string, fields []Field, v interface{}, discriminator string) error {
+	if v == nil {
+		v = map[string]interface{}{}
+	}
+
+	o, ok := v.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be an object", describe(path))
+	}
+
+	known := map[string]bool{}
+	for _, f := range fields {
+		known[f.Name] = true
+
+		value, ok := o[f.Name]
+		if !ok || value == nil {
+			if f.Required {
+				return fmt.Errorf("%s is required", join(path, f.Name))
+			}
+			continue
+		}
+
+		if err := s.checkField(join(path, f.Name), f, value); err != nil {
+			return err
+		}
+	}
+
+	// sorted for deterministic errors
+	var keys []string
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !known[k] && k != discriminator {
+			return fmt.Errorf("%s is not a field", join(path, k))
+		}
+	}
+
+	return nil
+}
+
+// checkField checks the value v at path matches field f.
+func (s *Schema) checkField(path string, f Field, v interface{}) error {
+	if f.Type.Ref.Value != "" {
+		t, ok := s.lookup(f.Type.Ref)
+		if !ok {
+			return fmt.Errorf("reference to undefined type %q", f.Type.Ref.Value)
+		}
+		return s.checkType(path, t, v)
+	}
+
+	switch f.Type.Type {
+	case String:
+		x, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", describe(path))
+		}
+		if f.Enum != nil && !contains(f.Enum, x) {
+			return fmt.Errorf("%s %q must be one of the enum values", describe(path), x)
+		}
+	case Int:
+		if x, ok := v.(float64); !ok || x != math.Trunc(x) {
+			return fmt.Errorf("%s must be an integer", describe(path))
+		}
+	case Float:
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s must be a number", describe(path))
+		}
+	case Bool:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", describe(path))
+		}
+	case Timestamp:
+		x, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a timestamp string", describe(path))
+		}
+		if _, err := time.Parse(time.RFC3339, x); err != nil {
+			return fmt.Errorf("%s %q is not a valid RFC 3339 timestamp", describe(path), x)
+		}
+	case Array:
+		a, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", describe(path))
+		}
+		for i, e := range a {
+			if err := s.checkField(fmt.Sprintf("%s[%d]", path, i), f.Items.Field(), e); err != nil {
+				return err
+			}
+		}
+	case Object:
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", describe(path))
+		}
+		if f.HasValues() {
+			var keys []string
+			for k := range o {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				if err := s.checkField(join(path, k), f.Values.Field(), o[k]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkType checks the value v at path matches type t.
+func (s *Schema) checkType(path string, t Type, v interface{}) error {
+	switch {
+	case t.IsEnum():
+		x, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", describe(path))
+		}
+		if !contains(t.Enum, x) {
+			return fmt.Errorf("%s %q must be one of the enum values", describe(path), x)
+		}
+		return nil
+	case t.IsUnion():
+		o, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", describe(path))
+		}
+
+		name, _ := o[t.Discriminator].(string)
+		for _, ref := range t.OneOf {
+			variant, ok := s.lookup(ref)
+			if ok && variant.Name == name {
+				return s.checkObject(path, variant.Properties, v, t.Discriminator)
+			}
+		}
+		return fmt.Errorf("%s must be one of the variants", describe(join(path, t.Discriminator)))
+	default:
+		return s.checkObject(path, t.Properties, v, "")
+	}
+}
+
+// join returns the path of property k within path.
+func join(path, k string) string {
+	if path == "" {
+		return k
+	}
+	return path + "." + k
+}
+
+// describe returns path, or "value" for the root.
+func describe(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return path
+}
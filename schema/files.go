@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// files is a source of schema files.
+type files interface {
+	// ReadFile returns the contents of the file at name.
+	ReadFile(name string) ([]byte, error)
+
+	// Glob returns the names of the files matching pattern.
+	Glob(pattern string) ([]string, error)
+
+	// Join returns the name of file rel, relative to the directory of file.
+	Join(file, rel string) string
+
+	// Clean returns the shortest name equivalent to name.
+	Clean(name string) string
+}
+
+// osFiles are the files of the operating system.
+type osFiles struct{}
+
+// ReadFile implementation.
+func (osFiles) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name)
+}
+
+// Glob implementation.
+func (osFiles) Glob(pattern string) ([]string, error) {
+	return filepath.Glob(pattern)
+}
+
+// Join implementation.
+func (osFiles) Join(file, rel string) string {
+	return filepath.Join(filepath.Dir(file), rel)
+}
+
+// Clean implementation.
+func (osFiles) Clean(name string) string {
+	return filepath.Clean(name)
+}
+
+// fsFiles are the files of a file system such as an embed.FS.
+type fsFiles struct {
+	fs.FS
+}
+
+// ReadFile implementation.
+func (f fsFiles) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(f.FS, name)
+}
+
+// Glob implementation.
+func (f fsFiles) Glob(pattern string) ([]string, error) {
+	return fs.Glob(f.FS, pattern)
+}
+
+// Join implementation.
+func (fsFiles) Join(file, rel string) string {
+	return path.Join(path.Dir(file), rel)
+}
+
+// Clean implementation.
+func (fsFiles) Clean(name string) string {
+	return path.Clean(name)
+}
+
+// memFiles serve a file from memory, and any others from files.
+type memFiles struct {
+	files
+	name string
+	data []byte
+}
+
+// ReadFile implementation.
+func (m memFiles) ReadFile(name string) ([]byte, error) {
+	if name == m.name {
+		return m.data, nil
+	}
+	return m.files.ReadFile(name)
+}
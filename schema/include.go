@@ -0,0 +1,252 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolver merges included and referenced schema files into a schema.
+type resolver struct {
+	files  files
+	schema *Schema
+	root   string
+	loaded map[string]bool
+}
+
+// newResolver returns a resolver for schema s loaded from path in fsys.
+func newResolver(fsys files, s *Schema, path string) *resolver {
+	path = fsys.Clean(path)
+	return &resolver{
+		files:  fsys,
+		schema: s,
+		root:   path,
+		loaded: map[string]bool{path: true},
+	}
+}
+
+// resolve merges the included files, then rewrites references such as
+// "common.json#/types/money" to the merged types, loading the types of
+// referenced files which were not included.
+func (r *resolver) resolve() error {
+	if r.schema.Types == nil {
+		r.schema.Types = map[string]Type{}
+	}
+
+	err := r.include(r.root, r.schema.Include)
+	if err != nil {
+		return err
+	}
+
+	return r.references()
+}
+
+// include merges the files matching patterns, relative to the file at path.
+func (r *resolver) include(path string, patterns []string) error {
+	for _, pattern := range patterns {
+		matches, err := r.files.Glob(r.files.Join(path, pattern))
+		if err != nil {
+			return fmt.Errorf("%s: include %q: %w", path, pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("%s: include %q matched no files", path, pattern)
+		}
+
+		for _, m := range matches {
+			if r.loaded[m] {
+				continue
+			}
+
+			f, err := r.load(m)
+			if err != nil {
+				return err
+			}
+
+			err = r.merge(f)
+			if err != nil {
+				return err
+			}
+
+			err = r.include(m, f.Include)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// paths returns the paths of the loaded files, the root file first.
+func (r *resolver) paths() []string {
+	v := []string{r.root}
+	for path := range r.loaded {
+		if path != r.root {
+			v = append(v, path)
+		}
+	}
+	sort.Strings(v[1:])
+	return v
+}
+
+// load returns the file at path, merging its types.
+func (r *resolver) load(path string) (*Schema, error) {
+	r.loaded[path] = true
+
+	f, err := loadFile(r.files, path, fragmentSchema())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range f.TypesSlice() {
+		if prev, ok := r.schema.Types[t.Name]; ok {
+			return nil, fmt.Errorf("%s: type %q already declared in %s", path, t.Name, prev.File)
+		}
+		r.schema.Types[t.Name] = t
+	}
+
+	return f, nil
+}
+
+// merge the methods and groups of included file f.
+func (r *resolver) merge(f *Schema) error {
+	for _, m := range f.Methods {
+		for _, prev := range r.schema.Methods {
+			if prev.Name == m.Name {
+				return fmt.Errorf("%s: method %q already declared in %s", m.File, m.Name, prev.File)
+			}
+		}
+		r.schema.Methods = append(r.schema.Methods, m)
+	}
+
+	r.schema.Groups = append(r.schema.Groups, f.Groups...)
+	return nil
+}
+
+// references rewrites the references of all methods and types.
+func (r *resolver) references() error {
+	for i := range r.schema.Methods {
+		m := &r.schema.Methods[i]
+
+		for j := range m.Inputs {
+			if err := r.field(m.File, &m.Inputs[j]); err != nil {
+				return fmt.Errorf("%s: method %q: %w", m.File, m.Name, err)
+			}
+		}
+
+		for j := range m.Outputs {
+			if err := r.field(m.File, &m.Outputs[j]); err != nil {
+				return fmt.Errorf("%s: method %q: %w", m.File, m.Name, err)
+			}
+		}
+	}
+
+	// referenced files may add types as we go
+	done := map[string]bool{}
+	for len(done) < len(r.schema.Types) {
+		for _, t := range r.schema.TypesSlice() {
+			if done[t.Name] {
+				continue
+			}
+			done[t.Name] = true
+
+			for j := range t.Properties {
+				if err := r.field(t.File, &t.Properties[j]); err != nil {
+					return fmt.Errorf("%s: type %q: %w", t.File, t.Name, err)
+				}
+			}
+
+			for j := range t.OneOf {
+				if err := r.ref(t.File, &t.OneOf[j]); err != nil {
+					return fmt.Errorf("%s: type %q: %w", t.File, t.Name, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// field rewrites the references of field f declared in file.
+func (r *resolver) field(file string, f *Field) error {
+	if err := r.ref(file, &f.Type.Ref); err != nil {
+		return err
+	}
+
+	if err := r.items(file, &f.Items); err != nil {
+		return err
+	}
+
+	return r.items(file, &f.Values)
+}
+
+// items rewrites the references of item definition i declared in file.
+func (r *resolver) items(file string, i *ItemsObject) error {
+	if err := r.ref(file, &i.Ref); err != nil {
+		return err
+	}
+
+	if i.Items != nil {
+		if err := r.items(file, i.Items); err != nil {
+			return err
+		}
+	}
+
+	if i.Values != nil {
+		return r.items(file, i.Values)
+	}
+
+	return nil
+}
+
+// ref rewrites ref declared in file to the merged type it references.
+func (r *resolver) ref(file string, ref *Ref) error {
+	if ref.Value == "" {
+		return nil
+	}
+
+	target, fragment := ref.Value, ""
+	if i := strings.Index(ref.Value, "#"); i != -1 {
+		target, fragment = ref.Value[:i], ref.Value[i+1:]
+	}
+
+	// load referenced file
+	path := ""
+	if target != "" {
+		path = r.files.Join(file, target)
+		if !r.loaded[path] {
+			if _, err := r.load(path); err != nil {
+				return fmt.Errorf("reference %q: %w", ref.Value, err)
+			}
+		}
+	}
+
+	name := strings.TrimPrefix(fragment, "/types/")
+	t, ok := r.schema.Types[name]
+	if !ok || (path != "" && t.File != path) {
+		return fmt.Errorf("reference to undefined type %q", ref.Value)
+	}
+
+	ref.Value = "#/types/" + name
+	return nil
+}
+
+// fragmentSchema returns the meta-schema for included and referenced
+// files, which don't require the properties of a root schema.
+func fragmentSchema() []byte {
+	var m map[string]interface{}
+	if err := json.Unmarshal(SchemaJson, &m); err != nil {
+		panic(err)
+	}
+
+	delete(m, "required")
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
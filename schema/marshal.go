@@ -0,0 +1,278 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/newlix/rpc/internal/canonical"
+)
+
+// object is a JSON object of the schema document.
+type object = map[string]interface{}
+
+// MarshalJSON implementation. The document has its properties in canonical
+// order, and loads back into an equivalent schema, with included and
+// referenced files merged into it.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	doc := object{
+		"name":    s.Name,
+		"version": s.Version,
+		"methods": s.methods(),
+	}
+
+	set(doc, "description", s.Description)
+
+	if len(s.Groups) > 0 {
+		var groups []object
+		for _, g := range s.Groups {
+			o := object{"name": g.Name}
+			set(o, "description", g.Description)
+			set(o, "summary", g.Summary)
+			groups = append(groups, o)
+		}
+		doc["groups"] = groups
+	}
+
+	if types := s.types(); len(types) > 0 {
+		doc["types"] = types
+	}
+
+	if len(s.Go.Tags) > 0 || len(s.Go.Types) > 0 {
+		o := object{}
+		set(o, "tags", s.Go.Tags)
+		if len(s.Go.Types) > 0 {
+			o["types"] = mappingTypes(s.Go.Types)
+		}
+		doc["go"] = o
+	}
+
+	for k, t := range map[string]Target{"ts": s.TS, "swift": s.Swift, "kotlin": s.Kotlin} {
+		if len(t.Types) > 0 {
+			doc[k] = object{"types": mappingTypes(t.Types)}
+		}
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = canonical.Write(&buf, b, "json")
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// methods returns the methods of the document.
+func (s *Schema) methods() []object {
+	methods := []object{}
+	for _, m := range s.Methods {
+		o := object{"name": m.Name}
+		set(o, "description", m.Description)
+		set(o, "group", m.Group)
+		set(o, "private", m.Private)
+		deprecation(o, m.Deprecated)
+
+		if len(m.Inputs) > 0 {
+			o["inputs"] = fields(m.Inputs)
+		}
+
+		if len(m.Outputs) > 0 {
+			o["outputs"] = fields(m.Outputs)
+		}
+
+		if len(m.Examples) > 0 {
+			var examples []object
+			for _, e := range m.Examples {
+				x := object{}
+				set(x, "name", e.Name)
+				set(x, "description", e.Description)
+				set(x, "input", e.Input)
+				set(x, "output", e.Output)
+				examples = append(examples, x)
+			}
+			o["examples"] = examples
+		}
+
+		methods = append(methods, o)
+	}
+	return methods
+}
+
+// types returns the types of the document, without the input variants
+// derived from them.
+func (s *Schema) types() object {
+	types := object{}
+	for name, t := range s.Types {
+		if t.InputOf != "" {
+			continue
+		}
+
+		o := object{}
+		set(o, "description", t.Description)
+		set(o, "private", t.Private)
+		deprecation(o, t.Deprecated)
+		mappings(o, t.Mappings)
+
+		if len(t.Properties) > 0 {
+			o["properties"] = fields(t.Properties)
+		}
+
+		if len(t.Enum) > 0 {
+			o["enum"] = t.Enum
+		}
+
+		if len(t.OneOf) > 0 {
+			o["oneOf"] = t.OneOf
+			set(o, "discriminator", t.Discriminator)
+		}
+
+		if len(t.Examples) > 0 {
+			var examples []object
+			for _, e := range t.Examples {
+				x := object{"value": e.Value}
+				set(x, "description", e.Description)
+				examples = append(examples, x)
+			}
+			o["examples"] = examples
+		}
+
+		types[name] = o
+	}
+	return types
+}
+
+// fields returns the fields of the document.
+func fields(list []Field) []object {
+	var v []object
+	for _, f := range list {
+		o := object{"name": f.Name}
+		set(o, "description", f.Description)
+		set(o, "required", f.Required)
+		set(o, "readonly", f.ReadOnly)
+		set(o, "enum", f.Enum)
+		set(o, "pattern", f.Pattern)
+		set(o, "format", string(f.Format))
+		deprecation(o, f.Deprecated)
+		mappings(o, f.Mappings)
+
+		// defaults such as false are not omitted
+		if f.Default != nil {
+			o["default"] = f.Default
+		}
+
+		if f.Type.Ref.Value != "" {
+			o["type"] = f.Type.Ref
+		} else {
+			o["type"] = f.Type.Type
+		}
+
+		if f.Items.Type != "" || f.Items.Ref.Value != "" {
+			o["items"] = items(f.Items)
+		}
+
+		if f.HasValues() {
+			o["values"] = items(f.Values)
+		}
+
+		if f.MinLength != nil {
+			o["minLength"] = *f.MinLength
+		}
+
+		if f.MaxLength != nil {
+			o["maxLength"] = *f.MaxLength
+		}
+
+		if f.Minimum != nil {
+			o["minimum"] = *f.Minimum
+		}
+
+		if f.Maximum != nil {
+			o["maximum"] = *f.Maximum
+		}
+
+		v = append(v, o)
+	}
+	return v
+}
+
+// items returns the item or value definition i of the document.
+func items(i ItemsObject) object {
+	o := object{}
+	set(o, "$ref", i.Ref.Value)
+	set(o, "type", string(i.Type))
+
+	if i.Items != nil {
+		o["items"] = items(*i.Items)
+	}
+
+	if i.Values != nil {
+		o["values"] = items(*i.Values)
+	}
+
+	return o
+}
+
+// deprecation sets the deprecation d of o, which is true without a message or sunset.
+func deprecation(o object, d *Deprecation) {
+	switch {
+	case d == nil:
+	case d.Message == "" && d.Sunset == "":
+		o["deprecated"] = true
+	default:
+		v := object{}
+		set(v, "message", d.Message)
+		set(v, "sunset", d.Sunset)
+		o["deprecated"] = v
+	}
+}
+
+// mappings sets the type mappings m of o.
+func mappings(o object, m Mappings) {
+	for k, v := range map[string]*Mapping{"go": m.Go, "ts": m.TS, "swift": m.Swift, "kotlin": m.Kotlin} {
+		if v != nil {
+			o[k] = mapping(*v)
+		}
+	}
+}
+
+// mappingTypes returns the type mappings keyed by kind of the document.
+func mappingTypes(types map[Kind]Mapping) object {
+	o := object{}
+	for k, m := range types {
+		o[string(k)] = mapping(m)
+	}
+	return o
+}
+
+// mapping returns the type mapping m of the document.
+func mapping(m Mapping) object {
+	o := object{"type": m.Type}
+	set(o, "import", m.Import)
+	set(o, "zero", m.Zero)
+	return o
+}
+
+// set sets the property k of o to v, unless v is the zero value.
+func set(o object, k string, v interface{}) {
+	switch x := v.(type) {
+	case nil:
+		return
+	case string:
+		if x == "" {
+			return
+		}
+	case bool:
+		if !x {
+			return
+		}
+	case []string:
+		if len(x) == 0 {
+			return
+		}
+	}
+	o[k] = v
+}
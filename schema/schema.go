@@ -6,7 +6,9 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
+	"io/fs"
+	"path/filepath"
 	"sort"
 
 	"github.com/xeipuuv/gojsonschema"
@@ -40,6 +42,16 @@ const (
 	Timestamp Kind = "timestamp"
 )
 
+// Format is a string format.
+type Format string
+
+// Formats available.
+const (
+	Email    Format = "email"
+	URI      Format = "uri"
+	Hostname Format = "hostname"
+)
+
 // Ref model.
 type Ref struct {
 	Value string `json:"$ref"`
@@ -77,6 +89,28 @@ func (t *TypeObject) UnmarshalJSON(b []byte) error {
 type ItemsObject struct {
 	Type Kind `json:"type"`
 	Ref
+	Items  *ItemsObject `json:"items"`
+	Values *ItemsObject `json:"values"`
+}
+
+// Field returns the item definition as a field, for resolving its type.
+func (i ItemsObject) Field() Field {
+	f := Field{
+		Type: TypeObject{
+			Type: i.Type,
+			Ref:  i.Ref,
+		},
+	}
+
+	if i.Items != nil {
+		f.Items = *i.Items
+	}
+
+	if i.Values != nil {
+		f.Values = *i.Values
+	}
+
+	return f
 }
 
 // Schema model.
@@ -87,9 +121,38 @@ type Schema struct {
 	Methods     []Method        `json:"methods"`
 	Groups      []Group         `json:"groups"`
 	Types       map[string]Type `json:"types"`
+	Include     []string        `json:"include"`
 	Go          struct {
-		Tags []string `json:"tags"`
+		Tags  []string         `json:"tags"`
+		Types map[Kind]Mapping `json:"types"`
 	} `json:"go"`
+	TS     Target `json:"ts"`
+	Swift  Target `json:"swift"`
+	Kotlin Target `json:"kotlin"`
+
+	// Files are the paths of the schema files loaded, the root file first.
+	Files []string `json:"-"`
+}
+
+// Target model of the options of a target language.
+type Target struct {
+	Types map[Kind]Mapping `json:"types"`
+}
+
+// Mapping model of a type of a target language, used in place of the
+// generated type of a field, type or kind.
+type Mapping struct {
+	Type   string `json:"type"`
+	Import string `json:"import"`
+	Zero   string `json:"zero"`
+}
+
+// Mappings model of the types of each target language.
+type Mappings struct {
+	Go     *Mapping `json:"go"`
+	TS     *Mapping `json:"ts"`
+	Swift  *Mapping `json:"swift"`
+	Kotlin *Mapping `json:"kotlin"`
 }
 
 // Method model.
@@ -97,10 +160,14 @@ type Method struct {
 	Name        string          `json:"name"`
 	Description string          `json:"description"`
 	Private     bool            `json:"private"`
+	Deprecated  *Deprecation    `json:"deprecated"`
 	Group       string          `json:"group"`
 	Inputs      []Field         `json:"inputs"`
 	Outputs     []Field         `json:"outputs"`
 	Examples    []MethodExample `json:"examples"`
+
+	// File is the path of the schema file declaring the method.
+	File string `json:"-"`
 }
 
 // MethodExample model.
@@ -113,23 +180,92 @@ type MethodExample struct {
 
 // Field model.
 type Field struct {
-	Name        string      `json:"name"`
-	Description string      `json:"description"`
-	Required    bool        `json:"required"`
-	ReadOnly    bool        `json:"readonly"`
-	Default     interface{} `json:"default"`
-	Type        TypeObject  `json:"type"`
-	Items       ItemsObject `json:"items"`
-	Enum        []string    `json:"enum"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Required    bool         `json:"required"`
+	ReadOnly    bool         `json:"readonly"`
+	Default     interface{}  `json:"default"`
+	Type        TypeObject   `json:"type"`
+	Items       ItemsObject  `json:"items"`
+	Values      ItemsObject  `json:"values"`
+	Enum        []string     `json:"enum"`
+	Deprecated  *Deprecation `json:"deprecated"`
+	MinLength   *int         `json:"minLength"`
+	MaxLength   *int         `json:"maxLength"`
+	Pattern     string       `json:"pattern"`
+	Minimum     *float64     `json:"minimum"`
+	Maximum     *float64     `json:"maximum"`
+	Format      Format       `json:"format"`
+	Mappings
+}
+
+// HasValues returns true if the object field declares the type of its values.
+func (f Field) HasValues() bool {
+	return f.Values.Type != "" || f.Values.Ref.Value != ""
 }
 
 // Type model.
 type Type struct {
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Private     bool      `json:"private"`
-	Properties  []Field   `json:"properties"`
-	Examples    []Example `json:"examples"`
+	Name          string       `json:"name"`
+	Description   string       `json:"description"`
+	Private       bool         `json:"private"`
+	Properties    []Field      `json:"properties"`
+	Enum          []string     `json:"enum"`
+	OneOf         []Ref        `json:"oneOf"`
+	Discriminator string       `json:"discriminator"`
+	Deprecated    *Deprecation `json:"deprecated"`
+	Examples      []Example    `json:"examples"`
+	Mappings
+
+	// File is the path of the schema file declaring the type.
+	File string `json:"-"`
+
+	// InputOf is the name of the type which this type is the input variant
+	// of, without its read-only fields.
+	InputOf string `json:"-"`
+}
+
+// IsEnum returns true if the type is an enumeration of string values.
+func (t Type) IsEnum() bool {
+	return len(t.Enum) > 0
+}
+
+// IsUnion returns true if the type is a discriminated union of other types,
+// where the value of the Discriminator property is the variant type name.
+func (t Type) IsUnion() bool {
+	return len(t.OneOf) > 0
+}
+
+// Deprecation model.
+type Deprecation struct {
+	Message string `json:"message"`
+	Sunset  string `json:"sunset"`
+}
+
+// UnmarshalJSON implementation.
+func (d *Deprecation) UnmarshalJSON(b []byte) error {
+	// "deprecated": true
+	if b[0] != '{' {
+		return nil
+	}
+
+	// "deprecated": { "message": ... }
+	type deprecation Deprecation
+	return json.Unmarshal(b, (*deprecation)(d))
+}
+
+// Notice returns the deprecation message, including the sunset date when present.
+func (d Deprecation) Notice() string {
+	s := d.Message
+	if s == "" {
+		s = "No longer supported."
+	}
+
+	if d.Sunset != "" {
+		s += " Removal is scheduled for " + d.Sunset + "."
+	}
+
+	return s
 }
 
 // Example model.
@@ -166,43 +302,58 @@ func (s Schema) TypesSlice() (v []Type) {
 	return
 }
 
-// Load returns a schema loaded and validated from path.
+// Load returns a schema loaded and validated from path, merging in the
+// methods, types and groups of any included or referenced files.
 func Load(path string) (*Schema, error) {
-	// TODO: bake into the binary with Go's native 'embed' stuff once it's available
-	schema := gojsonschema.NewBytesLoader(SchemaJson)
-	doc := gojsonschema.NewReferenceLoader("file://" + path)
+	return load(osFiles{}, path)
+}
 
-	// validate
-	result, err := gojsonschema.Validate(schema, doc)
-	if err != nil {
-		return nil, err
-	}
+// LoadFS returns a schema loaded and validated from path in fsys, such as an
+// embed.FS, where included and referenced files are resolved.
+func LoadFS(fsys fs.FS, path string) (*Schema, error) {
+	return load(fsFiles{fsys}, path)
+}
 
-	if !result.Valid() {
-		return nil, &ValidationError{
-			Result: result,
-		}
+// LoadBytes returns a schema loaded and validated from b, where path is used
+// to detect the format, report errors and resolve included and referenced
+// files relative to it, such as "schema.yaml".
+func LoadBytes(path string, b []byte) (*Schema, error) {
+	return load(memFiles{
+		files: osFiles{},
+		name:  filepath.Clean(path),
+		data:  b,
+	}, path)
+}
+
+// LoadReader returns a schema loaded and validated from r, as with LoadBytes.
+func LoadReader(path string, r io.Reader) (*Schema, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
 	}
 
-	var s Schema
+	return LoadBytes(path, b)
+}
 
-	// open
-	f, err := os.Open(path)
+// load returns a schema loaded and validated from path in fsys.
+func load(fsys files, path string) (*Schema, error) {
+	s, err := loadFile(fsys, path, SchemaJson)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
-	// unmarshal
-	err = json.NewDecoder(f).Decode(&s)
+	// includes & references
+	r := newResolver(fsys, s, path)
+	err = r.resolve()
 	if err != nil {
 		return nil, err
 	}
+	s.Files = r.paths()
 
-	// populate type names
-	for k, v := range s.Types {
-		v.Name = k
-		s.Types[k] = v
+	// validate
+	err = s.validate()
+	if err != nil {
+		return nil, err
 	}
 
 	// sort groups
@@ -234,6 +385,54 @@ func Load(path string) (*Schema, error) {
 		})
 	}
 
+	return s, nil
+}
+
+// loadFile returns a single schema file validated against the given meta-schema.
+func loadFile(fsys files, path string, meta []byte) (*Schema, error) {
+	path = fsys.Clean(path)
+
+	b, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// normalize
+	b, err = Normalize(path, b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// validate
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(meta), gojsonschema.NewBytesLoader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if !result.Valid() {
+		return nil, fmt.Errorf("%s: %w", path, &ValidationError{
+			Result: result,
+		})
+	}
+
+	// unmarshal
+	var s Schema
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	// populate type names & origins
+	for k, v := range s.Types {
+		v.Name = k
+		v.File = path
+		s.Types[k] = v
+	}
+
+	for i := range s.Methods {
+		s.Methods[i].File = path
+	}
+
 	return &s, nil
 }
 
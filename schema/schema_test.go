@@ -0,0 +1,258 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc/schema"
+)
+
+// Test loading schemas.
+func TestLoad(t *testing.T) {
+	t.Run("with a valid schema", func(t *testing.T) {
+		s, err := schema.Load("../examples/todo/schema.json")
+		assert.NoError(t, err, "loading")
+		assert.Equal(t, "todo", s.Name)
+		assert.True(t, s.Types["reminder"].IsUnion())
+		assert.True(t, s.Types["priority"].IsEnum())
+		assert.Equal(t, "2027-06-01", s.Methods[3].Deprecated.Sunset)
+	})
+
+	t.Run("with nested collections", func(t *testing.T) {
+		s, err := schema.Load("../examples/todo/schema.json")
+		assert.NoError(t, err, "loading")
+		f := s.Methods[1].Outputs[1]
+		assert.Equal(t, "lists", f.Name)
+		assert.True(t, f.HasValues())
+		assert.Equal(t, schema.Array, f.Values.Field().Type.Type)
+		assert.Equal(t, "#/types/item", f.Values.Field().Items.Ref.Value)
+	})
+
+	t.Run("with a union missing the discriminator", func(t *testing.T) {
+		_, err := schema.Load("testdata/union_no_discriminator.json")
+		assert.EqualError(t, err, `testdata/union_no_discriminator.json: type "shape": union must declare a discriminator`)
+	})
+
+	t.Run("with a union of an undefined type", func(t *testing.T) {
+		_, err := schema.Load("testdata/union_undefined_variant.json")
+		assert.EqualError(t, err, `testdata/union_undefined_variant.json: type "shape": reference to undefined type "#/types/square"`)
+	})
+
+	t.Run("with a variant declaring the discriminator", func(t *testing.T) {
+		_, err := schema.Load("testdata/union_discriminator_property.json")
+		assert.EqualError(t, err, `testdata/union_discriminator_property.json: type "shape": variant "circle" must not declare the discriminator "kind"`)
+	})
+
+	t.Run("with a constraint of another kind", func(t *testing.T) {
+		_, err := schema.Load("testdata/constraint_kind.json")
+		assert.EqualError(t, err, `testdata/constraint_kind.json: method "get_user": field "id": minLength requires a string field`)
+	})
+
+	t.Run("with a string constraint on an enum", func(t *testing.T) {
+		_, err := schema.Load("testdata/constraint_enum.json")
+		assert.EqualError(t, err, `testdata/constraint_enum.json: method "set_mode": field "mode": maxLength does not apply to enum fields`)
+	})
+
+	t.Run("with an undefined group", func(t *testing.T) {
+		_, err := schema.Load("testdata/group_undefined.json")
+		assert.EqualError(t, err, `testdata/group_undefined.json: method "get_user": undefined group "users"`)
+	})
+
+	t.Run("with an invalid sunset date", func(t *testing.T) {
+		_, err := schema.Load("testdata/deprecated_sunset.json")
+		assert.EqualError(t, err, `testdata/deprecated_sunset.json: method "get_user": sunset "2027-02-30" is not a valid date`)
+	})
+
+	t.Run("with a default of another kind", func(t *testing.T) {
+		_, err := schema.Load("testdata/default_kind.json")
+		assert.EqualError(t, err, `testdata/default_kind.json: method "get_users": field "limit": default must be an integer`)
+	})
+
+	t.Run("with a default outside of the enum", func(t *testing.T) {
+		_, err := schema.Load("testdata/default_enum.json")
+		assert.EqualError(t, err, `testdata/default_enum.json: type "user": field "role": default "owner" must be one of the enum values`)
+	})
+
+	t.Run("with an invalid method example", func(t *testing.T) {
+		_, err := schema.Load("testdata/example_input.json")
+		assert.EqualError(t, err, `testdata/example_input.json: method "get_users": example "first page": output: users[1].role "owner" must be one of the enum values`)
+	})
+
+	t.Run("with an invalid type example", func(t *testing.T) {
+		_, err := schema.Load("testdata/example_type.json")
+		assert.EqualError(t, err, `testdata/example_type.json: type "user": example 2: name is required`)
+	})
+}
+
+// Test loading multi-file schemas.
+func TestLoad_include(t *testing.T) {
+	t.Run("with includes and references", func(t *testing.T) {
+		s, err := schema.Load("testdata/include/schema.json")
+		assert.NoError(t, err, "loading")
+
+		var names []string
+		for _, m := range s.Methods {
+			names = append(names, m.Name)
+		}
+		assert.Equal(t, []string{"add_order", "get_balance", "get_products"}, names)
+		assert.Equal(t, "testdata/include/methods/orders.json", s.Methods[0].File)
+
+		assert.Equal(t, "testdata/include/common.json", s.Types["money"].File)
+		assert.Equal(t, []string{"testdata/include/schema.json", "testdata/include/common.json", "testdata/include/methods/orders.json", "testdata/include/methods/products.json"}, s.Files)
+		assert.Equal(t, "#/types/money", s.Methods[1].Outputs[0].Type.Ref.Value)
+		assert.Equal(t, "#/types/money", s.Methods[2].Outputs[0].Values.Ref.Value)
+		assert.Equal(t, "#/types/money", s.Types["order"].Properties[0].Type.Ref.Value)
+	})
+
+	t.Run("with a reference to an undefined type", func(t *testing.T) {
+		_, err := schema.Load("testdata/include_undefined.json")
+		assert.EqualError(t, err, `testdata/include_undefined.json: method "get_balance": reference to undefined type "include/common.json#/types/cash"`)
+	})
+
+	t.Run("with a type declared twice", func(t *testing.T) {
+		_, err := schema.Load("testdata/include_duplicate.json")
+		assert.EqualError(t, err, `testdata/include/common.json: type "money" already declared in testdata/include_duplicate.json`)
+	})
+}
+
+// Test loading YAML and JSON with comments.
+func TestLoad_formats(t *testing.T) {
+	exp, err := schema.Load("../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	for _, path := range []string{"testdata/todo.yaml", "testdata/todo.jsonc"} {
+		t.Run(path, func(t *testing.T) {
+			act, err := schema.Load(path)
+			assert.NoError(t, err, "loading")
+			assert.Equal(t, withoutFiles(exp), withoutFiles(act))
+		})
+	}
+
+	t.Run("with unquoted dates", func(t *testing.T) {
+		b, err := schema.Normalize("s.yaml", []byte("sunset: 2027-06-01\nat: 2026-10-19T09:00:00Z\n"))
+		assert.NoError(t, err, "normalizing")
+		assert.Equal(t, `{"at":"2026-10-19T09:00:00Z","sunset":"2027-06-01"}`, string(b))
+	})
+
+	t.Run("with an unterminated block comment", func(t *testing.T) {
+		_, err := schema.LoadBytes("s.json", []byte(`{"name": "x" /* oops`))
+		assert.EqualError(t, err, "s.json: unterminated block comment at line 1")
+
+		_, err = schema.LoadBytes("s.json", []byte("{\n\"name\": \"x\" /*/"))
+		assert.EqualError(t, err, "s.json: unterminated block comment at line 2")
+	})
+}
+
+// Test loading schemas from memory and file systems.
+func TestLoad_sources(t *testing.T) {
+	exp, err := schema.Load("../examples/todo/schema.json")
+	assert.NoError(t, err, "loading")
+
+	t.Run("with bytes", func(t *testing.T) {
+		b, err := os.ReadFile("testdata/todo.yaml")
+		assert.NoError(t, err, "reading")
+
+		act, err := schema.LoadBytes("todo.yaml", b)
+		assert.NoError(t, err, "loading")
+		assert.Equal(t, withoutFiles(exp), withoutFiles(act))
+	})
+
+	t.Run("with a reader", func(t *testing.T) {
+		_, err := schema.LoadReader("schema.json", strings.NewReader(`{ "name": "todo" }`))
+		assert.Contains(t, err.Error(), "schema.json: validation failed")
+	})
+
+	t.Run("with a file system", func(t *testing.T) {
+		s, err := schema.LoadFS(os.DirFS("testdata"), "include/schema.json")
+		assert.NoError(t, err, "loading")
+		assert.Len(t, s.Methods, 3)
+		assert.Equal(t, "include/common.json", s.Types["money"].File)
+		assert.Equal(t, "#/types/money", s.Methods[1].Outputs[0].Type.Ref.Value)
+	})
+}
+
+// Test marshaling schemas.
+func TestSchema_MarshalJSON(t *testing.T) {
+	for _, path := range []string{"../examples/todo/schema.json", "testdata/include/schema.json", "testdata/mapping.json"} {
+		t.Run(path, func(t *testing.T) {
+			exp, err := schema.Load(path)
+			assert.NoError(t, err, "loading")
+
+			b, err := json.Marshal(exp)
+			assert.NoError(t, err, "marshaling")
+
+			act, err := schema.LoadBytes("schema.json", b)
+			assert.NoError(t, err, "loading marshaled")
+
+			// included files are merged
+			exp.Include = nil
+			assert.Equal(t, withoutFiles(exp), withoutFiles(act))
+		})
+	}
+}
+
+// Test building schemas.
+func TestBuilder(t *testing.T) {
+	t.Run("with a valid schema", func(t *testing.T) {
+		s, err := schema.New("todo").
+			Version("1.0.0").
+			Type(schema.Type{
+				Name:        "item",
+				Description: "a to-do item.",
+				Properties: []schema.Field{
+					{Name: "text", Description: "the text.", Type: schema.TypeObject{Type: schema.String}},
+				},
+			}).
+			Method(schema.Method{Name: "add_item", Description: "adds an item."}).
+			Input(schema.Field{Name: "item", Description: "the item.", Required: true, Type: schema.TypeObject{Ref: schema.Ref{Value: "#/types/item"}}}).
+			Method(schema.Method{Name: "get_items", Description: "returns the items."}).
+			Output(schema.Field{Name: "items", Description: "the items.", Type: schema.TypeObject{Type: schema.Array}, Items: schema.ItemsObject{Ref: schema.Ref{Value: "#/types/item"}}}).
+			Build()
+
+		assert.NoError(t, err, "building")
+		assert.Equal(t, "todo", s.Name)
+		assert.Equal(t, "add_item", s.Methods[0].Name)
+		assert.True(t, s.Methods[0].Inputs[0].Required)
+		assert.Equal(t, "#/types/item", s.Methods[1].Outputs[0].Items.Ref.Value)
+		assert.Equal(t, "text", s.Types["item"].Properties[0].Name)
+	})
+
+	t.Run("with an invalid schema", func(t *testing.T) {
+		_, err := schema.New("todo").
+			Version("1.0.0").
+			Method(schema.Method{Name: "add_item", Description: "adds an item."}).
+			Input(schema.Field{Name: "item", Description: "the item.", Type: schema.TypeObject{Ref: schema.Ref{Value: "#/types/item"}}}).
+			Build()
+
+		assert.EqualError(t, err, `todo.json: method "add_item": reference to undefined type "#/types/item"`)
+	})
+
+	t.Run("with an input before any method", func(t *testing.T) {
+		_, err := schema.New("todo").
+			Input(schema.Field{Name: "item"}).
+			Build()
+
+		assert.EqualError(t, err, `input added before any method`)
+	})
+}
+
+// withoutFiles returns s without its files and the file origins of methods
+// and types.
+func withoutFiles(s *schema.Schema) *schema.Schema {
+	s.Files = nil
+
+	for i := range s.Methods {
+		s.Methods[i].File = ""
+	}
+
+	for k, t := range s.Types {
+		t.File = ""
+		s.Types[k] = t
+	}
+
+	return s
+}
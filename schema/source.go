@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Normalize returns the JSON equivalent of schema source b read from path.
+// Files with a .yaml or .yml extension are decoded as YAML, while any other
+// file is treated as JSON which may contain comments and trailing commas.
+func Normalize(path string, b []byte) ([]byte, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var n yaml.Node
+		if err := yaml.Unmarshal(b, &n); err != nil {
+			return nil, fmt.Errorf("decoding yaml: %w", err)
+		}
+
+		timestampsAsStrings(&n)
+
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return nil, fmt.Errorf("decoding yaml: %w", err)
+		}
+		return json.Marshal(v)
+	default:
+		return stripJSONC(b)
+	}
+}
+
+// timestampsAsStrings tags the scalars of n which YAML resolves to
+// timestamps, such as unquoted dates, as strings so that they are kept as
+// written rather than decoded to time.Time and re-encoded.
+func timestampsAsStrings(n *yaml.Node) {
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!timestamp" {
+		n.Tag = "!!str"
+	}
+
+	for _, c := range n.Content {
+		timestampsAsStrings(c)
+	}
+}
+
+// stripJSONC returns b with comments and trailing commas replaced by
+// whitespace, preserving the offsets reported by JSON syntax errors.
+func stripJSONC(b []byte) ([]byte, error) {
+	out := make([]byte, len(b))
+	copy(out, b)
+
+	blank := func(i, j int) {
+		for ; i < j; i++ {
+			if out[i] != '\n' {
+				out[i] = ' '
+			}
+		}
+	}
+
+	comma := -1
+	for i := 0; i < len(out); i++ {
+		switch c := out[i]; {
+		case c == '"':
+			// skip strings
+			for i++; i < len(out) && out[i] != '"'; i++ {
+				if out[i] == '\\' {
+					i++
+				}
+			}
+			comma = -1
+		case c == '/' && i+1 < len(out) && out[i+1] == '/':
+			j := i
+			for j < len(out) && out[j] != '\n' {
+				j++
+			}
+			blank(i, j)
+			i = j
+		case c == '/' && i+1 < len(out) && out[i+1] == '*':
+			j := i + 2
+			for j+1 < len(out) && !(out[j] == '*' && out[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(out) {
+				return nil, fmt.Errorf("unterminated block comment at line %d", bytes.Count(out[:i], []byte("\n"))+1)
+			}
+			blank(i, j+2)
+			i = j + 1
+		case c == ',':
+			comma = i
+		case c == '}' || c == ']':
+			if comma != -1 {
+				out[comma] = ' '
+			}
+			comma = -1
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+		default:
+			comma = -1
+		}
+	}
+
+	return out, nil
+}
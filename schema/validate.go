@@ -0,0 +1,232 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// validate performs the checks which the JSON schema can't express.
+func (s *Schema) validate() error {
+	groups := map[string]bool{}
+	for _, g := range s.Groups {
+		groups[g.Name] = true
+	}
+
+	for _, m := range s.Methods {
+		if err := validateDeprecation(m.Deprecated); err != nil {
+			return fmt.Errorf("%s: method %q: %w", m.File, m.Name, err)
+		}
+
+		if m.Group != "" && !groups[m.Group] {
+			return fmt.Errorf("%s: method %q: undefined group %q", m.File, m.Name, m.Group)
+		}
+
+		if err := s.validateFields(m.Inputs); err != nil {
+			return fmt.Errorf("%s: method %q: %w", m.File, m.Name, err)
+		}
+
+		if err := s.validateFields(m.Outputs); err != nil {
+			return fmt.Errorf("%s: method %q: %w", m.File, m.Name, err)
+		}
+	}
+
+	for _, t := range s.TypesSlice() {
+		if err := validateDeprecation(t.Deprecated); err != nil {
+			return fmt.Errorf("%s: type %q: %w", t.File, t.Name, err)
+		}
+
+		if err := s.validateFields(t.Properties); err != nil {
+			return fmt.Errorf("%s: type %q: %w", t.File, t.Name, err)
+		}
+
+		if t.IsUnion() {
+			if err := s.validateUnion(t); err != nil {
+				return fmt.Errorf("%s: type %q: %w", t.File, t.Name, err)
+			}
+		}
+	}
+
+	return s.validateExamples()
+}
+
+// validateFields checks the constraints and defaults of fields apply to their kind.
+func (s *Schema) validateFields(fields []Field) error {
+	for _, f := range fields {
+		if err := validateConstraints(f); err != nil {
+			return fmt.Errorf("field %q: %w", f.Name, err)
+		}
+
+		if err := s.validateDefault(f); err != nil {
+			return fmt.Errorf("field %q: %w", f.Name, err)
+		}
+
+		if err := validateDeprecation(f.Deprecated); err != nil {
+			return fmt.Errorf("field %q: %w", f.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateDefault checks the default value of field f matches its kind and
+// enum. Defaults are supported by scalar and enum fields only.
+func (s *Schema) validateDefault(f Field) error {
+	if f.Default == nil {
+		return nil
+	}
+
+	kind, enum := f.Type.Type, f.Enum
+	if f.Type.Ref.Value != "" {
+		t, ok := s.lookup(f.Type.Ref)
+		if !ok || !t.IsEnum() {
+			return fmt.Errorf("default is not supported by object fields")
+		}
+		kind, enum = String, t.Enum
+	}
+
+	switch kind {
+	case String:
+		v, ok := f.Default.(string)
+		if !ok {
+			return fmt.Errorf("default must be a string")
+		}
+		if enum != nil && !contains(enum, v) {
+			return fmt.Errorf("default %q must be one of the enum values", v)
+		}
+	case Int:
+		if v, ok := f.Default.(float64); !ok || v != math.Trunc(v) {
+			return fmt.Errorf("default must be an integer")
+		}
+	case Float:
+		if _, ok := f.Default.(float64); !ok {
+			return fmt.Errorf("default must be a number")
+		}
+	case Bool:
+		if _, ok := f.Default.(bool); !ok {
+			return fmt.Errorf("default must be a boolean")
+		}
+	default:
+		return fmt.Errorf("default is not supported by %s fields", kind)
+	}
+
+	return nil
+}
+
+// contains returns true if values contains v.
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDeprecation checks the sunset date of deprecation d is a valid date.
+func validateDeprecation(d *Deprecation) error {
+	if d == nil || d.Sunset == "" {
+		return nil
+	}
+
+	if _, err := time.Parse("2006-01-02", d.Sunset); err != nil {
+		return fmt.Errorf("sunset %q is not a valid date", d.Sunset)
+	}
+
+	return nil
+}
+
+// validateConstraints checks the constraints of field f apply to its kind.
+func validateConstraints(f Field) error {
+	kind := f.Type.Type
+	if f.Type.Ref.Value != "" {
+		kind = ""
+	}
+
+	if kind != String {
+		switch {
+		case f.MinLength != nil:
+			return fmt.Errorf("minLength requires a string field")
+		case f.MaxLength != nil:
+			return fmt.Errorf("maxLength requires a string field")
+		case f.Pattern != "":
+			return fmt.Errorf("pattern requires a string field")
+		case f.Format != "":
+			return fmt.Errorf("format requires a string field")
+		}
+	}
+
+	// enum values are constrained by the enum alone
+	if len(f.Enum) > 0 {
+		switch {
+		case f.MinLength != nil:
+			return fmt.Errorf("minLength does not apply to enum fields")
+		case f.MaxLength != nil:
+			return fmt.Errorf("maxLength does not apply to enum fields")
+		case f.Pattern != "":
+			return fmt.Errorf("pattern does not apply to enum fields")
+		case f.Format != "":
+			return fmt.Errorf("format does not apply to enum fields")
+		}
+	}
+
+	if kind != Int && kind != Float {
+		switch {
+		case f.Minimum != nil:
+			return fmt.Errorf("minimum requires a numeric field")
+		case f.Maximum != nil:
+			return fmt.Errorf("maximum requires a numeric field")
+		}
+	}
+
+	if kind == Int {
+		switch {
+		case f.Minimum != nil && *f.Minimum != math.Trunc(*f.Minimum):
+			return fmt.Errorf("minimum of an integer field must be an integer")
+		case f.Maximum != nil && *f.Maximum != math.Trunc(*f.Maximum):
+			return fmt.Errorf("maximum of an integer field must be an integer")
+		}
+	}
+
+	if f.Pattern != "" {
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateUnion checks the discriminator and variants of union t.
+func (s *Schema) validateUnion(t Type) error {
+	if t.Discriminator == "" {
+		return fmt.Errorf("union must declare a discriminator")
+	}
+
+	for _, ref := range t.OneOf {
+		v, ok := s.lookup(ref)
+		if !ok {
+			return fmt.Errorf("reference to undefined type %q", ref.Value)
+		}
+
+		if v.IsEnum() || v.IsUnion() {
+			return fmt.Errorf("variant %q must be an object type", v.Name)
+		}
+
+		for _, f := range v.Properties {
+			if f.Name == t.Discriminator {
+				return fmt.Errorf("variant %q must not declare the discriminator %q", v.Name, f.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// lookup returns the type referenced by ref.
+func (s *Schema) lookup(ref Ref) (Type, bool) {
+	t, ok := s.Types[strings.TrimPrefix(ref.Value, "#/types/")]
+	return t, ok
+}
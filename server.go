@@ -0,0 +1,379 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/newlix/rpc/internal/format"
+	"github.com/newlix/rpc/internal/schemautil"
+	"github.com/newlix/rpc/schema"
+)
+
+var (
+	contextType     = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType       = reflect.TypeOf((*error)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+	unmarshalerType = reflect.TypeOf((*stdjson.Unmarshaler)(nil)).Elem()
+)
+
+// Server serves the methods of a schema with the methods of an implementation,
+// routing requests like the generated Go server.
+type Server struct {
+	impl    interface{}
+	schema  *schema.Schema
+	methods map[string]method
+}
+
+// method of the implementation serving a schema method.
+type method struct {
+	schema.Method
+	fn reflect.Value
+	in reflect.Type
+}
+
+// NewServer returns a new server dispatching the methods of s to the methods
+// of impl named format.GoName(method.Name), such as AddItem for "add_item".
+// The methods must have a signature matching the schema method, such as:
+//
+//	AddItem(ctx context.Context, in AddItemInput) error
+//	GetItems(ctx context.Context) (*GetItemsOutput, error)
+//
+// where input and output structs declare a field for each schema field.
+// The health check of impl is used when it implements HealthChecker.
+func NewServer(impl interface{}, s *schema.Schema) (*Server, error) {
+	v := reflect.ValueOf(impl)
+	srv := &Server{
+		impl:    impl,
+		schema:  s,
+		methods: map[string]method{},
+	}
+
+	for _, m := range s.Methods {
+		name := format.GoName(m.Name)
+		fn := v.MethodByName(name)
+		if !fn.IsValid() {
+			return nil, fmt.Errorf("method %q: %T does not implement %s", m.Name, impl, name)
+		}
+
+		in, err := checkSignature(s, m, fn.Type())
+		if err != nil {
+			return nil, fmt.Errorf("method %q: %s: %w", m.Name, name, err)
+		}
+
+		srv.methods[m.Name] = method{
+			Method: m,
+			fn:     fn,
+			in:     in,
+		}
+	}
+
+	return srv, nil
+}
+
+// checkSignature checks the signature t of the method implementing m of s,
+// returning the input type, if any.
+func checkSignature(s *schema.Schema, m schema.Method, t reflect.Type) (in reflect.Type, err error) {
+	// params
+	params := 1
+	if len(m.Inputs) > 0 {
+		params = 2
+	}
+
+	if t.NumIn() != params || t.In(0) != contextType {
+		if params == 2 {
+			return nil, fmt.Errorf("must accept a context.Context and an input struct")
+		}
+		return nil, fmt.Errorf("must accept a context.Context only")
+	}
+
+	if params == 2 {
+		in = t.In(1)
+		if err := checkFields(s, in, m.Inputs); err != nil {
+			return nil, fmt.Errorf("input: %w", err)
+		}
+	}
+
+	// results
+	results := 1
+	if len(m.Outputs) > 0 {
+		results = 2
+	}
+
+	if t.NumOut() != results || t.Out(results-1) != errorType {
+		if results == 2 {
+			return nil, fmt.Errorf("must return an output struct and an error")
+		}
+		return nil, fmt.Errorf("must return an error only")
+	}
+
+	if results == 2 {
+		if err := checkFields(s, t.Out(0), m.Outputs); err != nil {
+			return nil, fmt.Errorf("output: %w", err)
+		}
+	}
+
+	return in, nil
+}
+
+// checkFields checks the struct t, or pointer to a struct, declares fields
+// of Go types holding their values.
+func checkFields(s *schema.Schema, t reflect.Type, fields []schema.Field) error {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%s must be a struct", t)
+	}
+
+	for _, f := range fields {
+		sf, ok := structField(t, f.Name)
+		if !ok {
+			return fmt.Errorf("%s must declare field %q", t, f.Name)
+		}
+
+		if _, ok := schemautil.Mapping(s, schemautil.Go, f); ok {
+			continue
+		}
+
+		if want, ok := checkKind(s, sf.Type, f); !ok {
+			return fmt.Errorf("%s field %s must be %s for %q", t, sf.Name, want, f.Name)
+		}
+	}
+
+	return nil
+}
+
+// checkKind returns true if the Go type t holds the values of field f,
+// otherwise a description of the expected type. Interfaces and other types
+// decoding JSON themselves hold any value.
+func checkKind(s *schema.Schema, t reflect.Type, f schema.Field) (string, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Interface || t != timeType && reflect.PtrTo(t).Implements(unmarshalerType) {
+		return "", true
+	}
+
+	if ref := f.Type.Ref; ref.Value != "" {
+		if schemautil.ResolveRef(s, ref).IsEnum() {
+			return "a string", t.Kind() == reflect.String
+		}
+		return "a struct", t.Kind() == reflect.Struct
+	}
+
+	switch f.Type.Type {
+	case schema.String:
+		// byte slices are encoded as base64 strings
+		raw := t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+		return "a string", t.Kind() == reflect.String || raw
+	case schema.Int:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return "", true
+		}
+		return "an integer", false
+	case schema.Float:
+		return "a float", t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+	case schema.Bool:
+		return "a bool", t.Kind() == reflect.Bool
+	case schema.Timestamp:
+		return "a time.Time", t == timeType
+	case schema.Array:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return "a slice", false
+		}
+		want, ok := checkKind(s, t.Elem(), f.Items.Field())
+		return "a slice of " + strings.TrimPrefix(strings.TrimPrefix(want, "a "), "an "), ok
+	case schema.Object:
+		if t.Kind() == reflect.Map && t.Key().Kind() == reflect.String {
+			if !f.HasValues() {
+				return "", true
+			}
+			want, ok := checkKind(s, t.Elem(), f.Values.Field())
+			return "a map of " + strings.TrimPrefix(strings.TrimPrefix(want, "a "), "an "), ok
+		}
+		return "a map", !f.HasValues() && t.Kind() == reflect.Struct
+	default:
+		return "", true
+	}
+}
+
+// structField returns the exported field of struct t encoded as the JSON
+// property name, by its json tag, or otherwise its name compared without
+// case as encoding/json does.
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		switch {
+		case tag == "-":
+		case tag != "":
+			if tag == name {
+				return f, true
+			}
+		case strings.EqualFold(f.Name, name):
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// ServeHTTP implementation.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		switch r.URL.Path {
+		case "/_health":
+			WriteHealth(w, s.impl)
+		default:
+			WriteError(w, BadRequest("Invalid method"))
+		}
+		return
+	}
+
+	if r.Method == "POST" {
+		m, ok := s.methods[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			WriteError(w, BadRequest("Invalid method"))
+			return
+		}
+
+		res, err := s.call(w, r, m)
+		if err != nil {
+			WriteError(w, err)
+			return
+		}
+
+		WriteResponse(w, res)
+	}
+}
+
+// call method m with the request input, returning its output.
+func (s *Server) call(w http.ResponseWriter, r *http.Request, m method) (interface{}, error) {
+	// deprecation
+	if d := m.Deprecated; d != nil {
+		w.Header().Set("Deprecation", "true")
+		if t, err := time.Parse("2006-01-02", d.Sunset); err == nil {
+			w.Header().Set("Sunset", t.Format(http.TimeFormat))
+		}
+	}
+
+	args := []reflect.Value{
+		reflect.ValueOf(NewRequestContext(r.Context(), r)),
+	}
+
+	// parse input
+	if m.in != nil {
+		in := reflect.New(m.in)
+		err := s.readInput(r, in.Interface(), m.Inputs)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, in.Elem())
+	}
+
+	out := m.fn.Call(args)
+
+	// error
+	if err, _ := out[len(out)-1].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	if len(out) == 1 {
+		return nil, nil
+	}
+
+	// nil output pointers respond with no content
+	if v := out[0]; v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+
+	return out[0].Interface(), nil
+}
+
+// readInput parses the request body into v like ReadRequest, applying the
+// defaults of the absent fields of the inputs and the types they reference.
+func (s *Server) readInput(r *http.Request, v interface{}, fields []schema.Field) error {
+	if r.Header.Get("Content-Type") == "application/json" {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			return BadRequest("Failed to read request body")
+		}
+
+		// numbers are kept as-is, so that integers beyond float64 precision survive
+		var body interface{}
+		d := stdjson.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if d.Decode(&body) == nil {
+			applyDefaults(s.schema, body, fields)
+			b, _ = stdjson.Marshal(body)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	return ReadRequest(r, v)
+}
+
+// applyDefaults sets the default values of the fields absent from, or null
+// in the JSON object v, and within the values of the other fields.
+func applyDefaults(s *schema.Schema, v interface{}, fields []schema.Field) {
+	o, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for _, f := range fields {
+		if o[f.Name] == nil {
+			if f.Default != nil {
+				o[f.Name] = f.Default
+			}
+			continue
+		}
+		applyValueDefaults(s, o[f.Name], f)
+	}
+}
+
+// applyValueDefaults sets the default values within the JSON value v of
+// field f, through references, arrays and maps.
+func applyValueDefaults(s *schema.Schema, v interface{}, f schema.Field) {
+	if ref := f.Type.Ref; ref.Value != "" {
+		t := schemautil.ResolveRef(s, ref)
+		if t.IsUnion() {
+			o, _ := v.(map[string]interface{})
+			for _, variant := range schemautil.Variants(s, t) {
+				if o[t.Discriminator] == variant.Name {
+					applyDefaults(s, v, variant.Properties)
+				}
+			}
+			return
+		}
+		applyDefaults(s, v, t.Properties)
+		return
+	}
+
+	switch f.Type.Type {
+	case schema.Array:
+		a, _ := v.([]interface{})
+		for _, e := range a {
+			applyValueDefaults(s, e, f.Items.Field())
+		}
+	case schema.Object:
+		o, _ := v.(map[string]interface{})
+		for _, e := range o {
+			applyValueDefaults(s, e, f.Values.Field())
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+
+	"github.com/newlix/rpc"
+	"github.com/newlix/rpc/schema"
+)
+
+// item model.
+type item struct {
+	ID       int    `json:"id"`
+	Text     string `json:"text"`
+	Priority string `json:"priority"`
+}
+
+// addItemInput params.
+type addItemInput struct {
+	Item     string `json:"item"`
+	Priority string `json:"priority"`
+}
+
+// getItemsOutput params.
+type getItemsOutput struct {
+	Items []item            `json:"items"`
+	Lists map[string][]item `json:"lists"`
+}
+
+// getStatsOutput params.
+type getStatsOutput struct {
+	Stats struct{} `json:"stats"`
+}
+
+// removeItemInput params.
+type removeItemInput struct {
+	ID int `json:"id"`
+}
+
+// removeItemOutput params.
+type removeItemOutput struct {
+	Item item `json:"item"`
+}
+
+// updateItemInput params.
+type updateItemInput struct {
+	ID   int
+	Item item
+}
+
+// todo is a to-do list implementation.
+type todo struct {
+	items []item
+}
+
+func (t *todo) AddItem(ctx context.Context, in addItemInput) error {
+	if in.Item == "fail" {
+		return errors.New("boom")
+	}
+	t.items = append(t.items, item{ID: len(t.items) + 1, Text: in.Item, Priority: in.Priority})
+	return nil
+}
+
+func (t *todo) GetItems(ctx context.Context) (*getItemsOutput, error) {
+	return &getItemsOutput{Items: t.items}, nil
+}
+
+func (t *todo) GetStats(ctx context.Context) (*getStatsOutput, error) {
+	return &getStatsOutput{}, nil
+}
+
+func (t *todo) RemoveItem(ctx context.Context, in removeItemInput) (*removeItemOutput, error) {
+	return nil, rpc.Error(http.StatusNotFound, "not_found", "Item not found")
+}
+
+func (t *todo) UpdateItem(ctx context.Context, in *updateItemInput) (*removeItemOutput, error) {
+	in.Item.ID = in.ID
+	return &removeItemOutput{Item: in.Item}, nil
+}
+
+// post method to h with body, returning the response.
+func post(h http.Handler, method, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/"+method, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+// Test reflection-based servers.
+func TestNewServer(t *testing.T) {
+	s, err := schema.Load("examples/todo/schema.json")
+	assert.NoError(t, err, "loading schema")
+
+	t.Run("with a matching implementation", func(t *testing.T) {
+		h, err := rpc.NewServer(&todo{}, s)
+		assert.NoError(t, err)
+
+		w := post(h, "add_item", `{ "item": "Buy milk" }`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		w = post(h, "get_items", ``)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{ "items": [{ "id": 1, "text": "Buy milk", "priority": "normal" }], "lists": null }`, w.Body.String())
+
+		w = post(h, "update_item", `{ "id": 1, "item": { "text": "Buy eggs" } }`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{ "item": { "id": 1, "text": "Buy eggs", "priority": "normal" } }`, w.Body.String())
+
+		w = post(h, "update_item", `{ "id": 1, "item": { "text": "Buy eggs", "priority": "low" } }`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{ "item": { "id": 1, "text": "Buy eggs", "priority": "low" } }`, w.Body.String())
+
+		w = post(h, "update_item", `{ "id": 9007199254740993, "item": { "text": "Buy eggs" } }`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"id": 9007199254740993,`)
+
+		w = post(h, "remove_item", `{ "id": 1 }`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
+		assert.Equal(t, "Tue, 01 Jun 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+
+		w = post(h, "add_item", `{ "item": "fail" }`)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		w = post(h, "add_items", `{}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		r := httptest.NewRequest("GET", "/_health", nil)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		assert.Equal(t, "OK\n", w.Body.String())
+	})
+
+	t.Run("with a missing method", func(t *testing.T) {
+		_, err := rpc.NewServer(&struct{}{}, s)
+		assert.EqualError(t, err, `method "add_item": *struct {} does not implement AddItem`)
+	})
+
+	t.Run("with a mismatched signature", func(t *testing.T) {
+		_, err := rpc.NewServer(&badTodo{}, s)
+		assert.EqualError(t, err, `method "add_item": AddItem: input: rpc_test.removeItemInput must declare field "item"`)
+	})
+
+	t.Run("with a mismatched field type", func(t *testing.T) {
+		_, err := rpc.NewServer(&badKindTodo{}, s)
+		assert.EqualError(t, err, `method "add_item": AddItem: input: rpc_test.badItemInput field Item must be a string for "item"`)
+	})
+
+	t.Run("with a byte slice for a string field", func(t *testing.T) {
+		h, err := rpc.NewServer(&bytesTodo{}, s)
+		assert.NoError(t, err)
+
+		w := post(h, "add_item", `{ "item": "QnV5IG1pbGs=" }`)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("with an untagged field named differently", func(t *testing.T) {
+		s, err := schema.LoadBytes("schema.json", []byte(`{
+			"name": "notes",
+			"version": "1.0.0",
+			"methods": [
+				{
+					"name": "add_note",
+					"description": "adds a note.",
+					"inputs": [{ "name": "created_by", "description": "the author.", "type": "string" }]
+				}
+			]
+		}`))
+		assert.NoError(t, err, "loading schema")
+
+		_, err = rpc.NewServer(&notes{}, s)
+		assert.EqualError(t, err, `method "add_note": AddNote: input: rpc_test.addNoteInput must declare field "created_by"`)
+	})
+}
+
+// badTodo is a to-do list implementation with the wrong add_item input.
+type badTodo struct {
+	todo
+}
+
+func (t *badTodo) AddItem(ctx context.Context, in removeItemInput) error {
+	return nil
+}
+
+// badItemInput params with an item of the wrong type.
+type badItemInput struct {
+	Item     int    `json:"item"`
+	Priority string `json:"priority"`
+}
+
+// badKindTodo is a to-do list implementation with the wrong add_item input field type.
+type badKindTodo struct {
+	todo
+}
+
+func (t *badKindTodo) AddItem(ctx context.Context, in badItemInput) error {
+	return nil
+}
+
+// bytesItemInput params with the item as base64 encoded bytes.
+type bytesItemInput struct {
+	Item     []byte `json:"item"`
+	Priority string `json:"priority"`
+}
+
+// bytesTodo is a to-do list implementation decoding items as bytes.
+type bytesTodo struct {
+	todo
+}
+
+func (t *bytesTodo) AddItem(ctx context.Context, in bytesItemInput) error {
+	if string(in.Item) != "Buy milk" {
+		return errors.New("boom")
+	}
+	return nil
+}
+
+// addNoteInput params without a json tag, which encoding/json decodes from "CreatedBy".
+type addNoteInput struct {
+	CreatedBy string
+}
+
+// notes is a notes implementation.
+type notes struct{}
+
+func (n *notes) AddNote(ctx context.Context, in addNoteInput) error {
+	return nil
+}